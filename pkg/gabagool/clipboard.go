@@ -0,0 +1,35 @@
+package gabagool
+
+import (
+	"sync"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// sessionClipboard backs the clipboard on video drivers without one (e.g. KMSDRM on
+// handhelds). It lives for the whole process, so text copied in one Keyboard can be
+// pasted into the next.
+var (
+	sessionClipboardMu sync.Mutex
+	sessionClipboard   string
+)
+
+func setClipboardText(text string) {
+	sessionClipboardMu.Lock()
+	sessionClipboard = text
+	sessionClipboardMu.Unlock()
+
+	_ = sdl.SetClipboardText(text)
+}
+
+func getClipboardText() string {
+	if sdl.HasClipboardText() {
+		if text, err := sdl.GetClipboardText(); err == nil && text != "" {
+			return text
+		}
+	}
+
+	sessionClipboardMu.Lock()
+	defer sessionClipboardMu.Unlock()
+	return sessionClipboard
+}
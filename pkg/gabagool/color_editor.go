@@ -0,0 +1,714 @@
+package gabagool
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// ColorEditorTab identifies one of the color editor's tabs.
+type ColorEditorTab int
+
+const (
+	ColorEditorTabQuickPick ColorEditorTab = iota // Swatch grid
+	ColorEditorTabHSV                             // Hue, saturation and value sliders
+	ColorEditorTabRGB                             // Red, green and blue sliders
+)
+
+var colorEditorTabNames = []string{"Quick Pick", "HSV", "RGB"}
+
+// ColorEditorSettings configures the color editor.
+type ColorEditorSettings struct {
+	// Palette replaces the default swatches shown in the quick-pick tab
+	Palette []sdl.Color
+	// AllowAlpha adds an alpha slider and includes the alpha channel in hex values
+	AllowAlpha bool
+	// InitialTab is the tab shown when the editor opens (default: ColorEditorTabQuickPick)
+	InitialTab ColorEditorTab
+	// OnChange is called every time the edited color changes, for live previews
+	OnChange func(sdl.Color)
+	// StatusBar configures the optional status bar in the top-right corner
+	StatusBar StatusBarOptions
+}
+
+// ColorEditorResult represents the result of the color editor.
+type ColorEditorResult struct {
+	// Color is the confirmed color
+	Color sdl.Color
+}
+
+const maxRecentColors = 8
+
+// recentColors holds the colors most recently confirmed in the color editor, newest first.
+// It lives for the duration of the process so every editor shares the same history.
+var recentColors []sdl.Color
+
+func addRecentColor(color sdl.Color) {
+	updated := []sdl.Color{color}
+	for _, c := range recentColors {
+		if c != color && len(updated) < maxRecentColors {
+			updated = append(updated, c)
+		}
+	}
+	recentColors = updated
+}
+
+type colorSlider struct {
+	label string
+	value int
+	max   int
+}
+
+type colorEditorController struct {
+	title    string
+	settings ColorEditorSettings
+	tab      ColorEditorTab
+
+	initial    sdl.Color
+	color      sdl.Color
+	hue        float64
+	saturation float64
+	value      float64
+
+	picker      *ColorPicker
+	recent      []sdl.Color
+	focusRow    int
+	recentIndex int
+
+	directionalInput internal.DirectionalInput
+	repeatCount      int
+	inputDelay       time.Duration
+	lastInputTime    time.Time
+	confirmed        bool
+	cancelled        bool
+}
+
+// ColorEditor displays a full color editor with a quick-pick swatch grid, HSV and RGB slider tabs,
+// hex entry through the Keyboard, an optional alpha channel and a row of recently used colors.
+// L1/R1 switch tabs, Y opens hex entry, A confirms and B cancels.
+// Returns ErrCancelled if the user presses B.
+func ColorEditor(title string, initial sdl.Color, settings ColorEditorSettings) (*ColorEditorResult, error) {
+	window := internal.GetWindow()
+	renderer := window.Renderer
+
+	if !settings.AllowAlpha {
+		initial.A = 255
+	}
+
+	picker := NewHexColorPicker(window)
+	if len(settings.Palette) > 0 {
+		picker.Colors = settings.Palette
+		picker.GridCols = int32(math.Ceil(math.Sqrt(float64(len(settings.Palette)))))
+		picker.GridRows = int32(math.Ceil(float64(len(settings.Palette)) / float64(picker.GridCols)))
+	}
+
+	c := &colorEditorController{
+		title:            title,
+		settings:         settings,
+		tab:              settings.InitialTab,
+		initial:          initial,
+		picker:           picker,
+		recent:           append([]sdl.Color(nil), recentColors...),
+		directionalInput: internal.NewDirectionalInputWithTiming(300*time.Millisecond, 50*time.Millisecond),
+		inputDelay:       constants.DefaultInputDelay,
+		lastInputTime:    time.Now(),
+	}
+	if c.tab < ColorEditorTabQuickPick || c.tab > ColorEditorTabRGB {
+		c.tab = ColorEditorTabQuickPick
+	}
+
+	c.color = initial
+	c.hue, c.saturation, c.value = internal.RGBToHSV(initial)
+	c.syncPickerSelection()
+
+	for !c.confirmed && !c.cancelled {
+		if err := c.handleEvents(); err != nil {
+			return nil, err
+		}
+
+		if dir := c.directionalInput.Update(); dir != internal.DirectionNone {
+			c.repeatCount++
+			c.navigate(dir.VirtualButton())
+		} else if !c.directionalInput.IsHeld() {
+			c.repeatCount = 0
+		}
+
+		c.render(renderer, window)
+	}
+
+	if c.cancelled {
+		return nil, ErrCancelled
+	}
+
+	addRecentColor(c.color)
+
+	return &ColorEditorResult{Color: c.color}, nil
+}
+
+func (c *colorEditorController) handleEvents() error {
+	processor := internal.GetInputProcessor()
+
+	event := sdl.WaitEventTimeout(16)
+	if event == nil {
+		return nil
+	}
+
+	switch event.(type) {
+	case *sdl.QuitEvent:
+		c.cancelled = true
+		return sdl.GetError()
+
+	case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.ControllerAxisEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent:
+		inputEvent := processor.ProcessSDLEvent(event.(sdl.Event))
+		if inputEvent == nil {
+			return nil
+		}
+
+		if !inputEvent.Pressed {
+			c.directionalInput.SetHeld(inputEvent.Button, false)
+			return nil
+		}
+
+		if time.Since(c.lastInputTime) < c.inputDelay {
+			return nil
+		}
+		c.lastInputTime = time.Now()
+
+		switch inputEvent.Button {
+		case constants.VirtualButtonUp, constants.VirtualButtonDown, constants.VirtualButtonLeft, constants.VirtualButtonRight:
+			c.directionalInput.SetHeld(inputEvent.Button, true)
+			c.repeatCount = 0
+			c.navigate(inputEvent.Button)
+		case constants.VirtualButtonL1:
+			c.switchTab(-1)
+		case constants.VirtualButtonR1:
+			c.switchTab(1)
+		case constants.VirtualButtonY:
+			c.enterHex()
+		case constants.VirtualButtonA, constants.VirtualButtonStart:
+			c.confirmed = true
+		case constants.VirtualButtonB:
+			c.cancelled = true
+		}
+	}
+
+	return nil
+}
+
+func (c *colorEditorController) rowCount() int {
+	rows := len(c.sliders())
+	if c.tab == ColorEditorTabQuickPick {
+		rows = 1
+	}
+	if len(c.recent) > 0 {
+		rows++
+	}
+	return rows
+}
+
+func (c *colorEditorController) recentRowFocused() bool {
+	return len(c.recent) > 0 && c.focusRow == c.rowCount()-1
+}
+
+func (c *colorEditorController) navigate(button constants.VirtualButton) {
+	if c.recentRowFocused() {
+		switch button {
+		case constants.VirtualButtonLeft:
+			c.recentIndex = internal.WrapInt(c.recentIndex-1, 0, len(c.recent)-1)
+			c.applyColor(c.recent[c.recentIndex])
+		case constants.VirtualButtonRight:
+			c.recentIndex = internal.WrapInt(c.recentIndex+1, 0, len(c.recent)-1)
+			c.applyColor(c.recent[c.recentIndex])
+		case constants.VirtualButtonUp:
+			c.focusRow--
+		case constants.VirtualButtonDown:
+			c.focusRow = 0
+		}
+		return
+	}
+
+	if c.tab == ColorEditorTabQuickPick {
+		c.navigateGrid(button)
+		return
+	}
+
+	switch button {
+	case constants.VirtualButtonUp:
+		c.focusRow = internal.WrapInt(c.focusRow-1, 0, c.rowCount()-1)
+	case constants.VirtualButtonDown:
+		c.focusRow = internal.WrapInt(c.focusRow+1, 0, c.rowCount()-1)
+	case constants.VirtualButtonLeft:
+		c.adjustSlider(c.focusRow, -c.sliderStep())
+	case constants.VirtualButtonRight:
+		c.adjustSlider(c.focusRow, c.sliderStep())
+	}
+}
+
+func (c *colorEditorController) navigateGrid(button constants.VirtualButton) {
+	lastRow := c.picker.SelectedIndex+int(c.picker.GridCols) >= len(c.picker.Colors)
+	if button == constants.VirtualButtonDown && lastRow && len(c.recent) > 0 {
+		c.focusRow = c.rowCount() - 1
+		return
+	}
+
+	var keycode sdl.Keycode
+	switch button {
+	case constants.VirtualButtonLeft:
+		keycode = sdl.K_LEFT
+	case constants.VirtualButtonRight:
+		keycode = sdl.K_RIGHT
+	case constants.VirtualButtonUp:
+		keycode = sdl.K_UP
+	case constants.VirtualButtonDown:
+		keycode = sdl.K_DOWN
+	}
+	c.picker.handleKeyPress(keycode)
+
+	picked := c.picker.getSelectedColor()
+	if c.settings.AllowAlpha {
+		picked.A = c.color.A
+	}
+	c.applyColor(picked)
+}
+
+// sliderStep speeds up slider changes the longer a direction is held.
+func (c *colorEditorController) sliderStep() int {
+	if c.repeatCount > 10 {
+		return 5
+	}
+	return 1
+}
+
+func (c *colorEditorController) switchTab(delta int) {
+	c.tab = ColorEditorTab(internal.WrapInt(int(c.tab)+delta, int(ColorEditorTabQuickPick), int(ColorEditorTabRGB)))
+	c.focusRow = 0
+	c.syncPickerSelection()
+}
+
+func (c *colorEditorController) sliders() []colorSlider {
+	var sliders []colorSlider
+	if c.tab == ColorEditorTabHSV {
+		sliders = []colorSlider{
+			{label: "H", value: int(math.Round(c.hue)) % 360, max: 359},
+			{label: "S", value: int(math.Round(c.saturation * 100)), max: 100},
+			{label: "V", value: int(math.Round(c.value * 100)), max: 100},
+		}
+	} else {
+		sliders = []colorSlider{
+			{label: "R", value: int(c.color.R), max: 255},
+			{label: "G", value: int(c.color.G), max: 255},
+			{label: "B", value: int(c.color.B), max: 255},
+		}
+	}
+
+	if c.settings.AllowAlpha {
+		sliders = append(sliders, colorSlider{label: "A", value: int(c.color.A), max: 255})
+	}
+
+	return sliders
+}
+
+func (c *colorEditorController) adjustSlider(index, delta int) {
+	sliders := c.sliders()
+	if index < 0 || index >= len(sliders) {
+		return
+	}
+
+	s := sliders[index]
+	newValue := s.value + delta
+	if s.label == "H" {
+		newValue = internal.WrapInt(newValue, 0, s.max)
+	} else if newValue < 0 {
+		newValue = 0
+	} else if newValue > s.max {
+		newValue = s.max
+	}
+
+	updated := c.color
+	switch s.label {
+	case "H":
+		c.hue = float64(newValue)
+		updated = internal.HSVToRGB(c.hue, c.saturation, c.value, c.color.A)
+	case "S":
+		c.saturation = float64(newValue) / 100
+		updated = internal.HSVToRGB(c.hue, c.saturation, c.value, c.color.A)
+	case "V":
+		c.value = float64(newValue) / 100
+		updated = internal.HSVToRGB(c.hue, c.saturation, c.value, c.color.A)
+	case "R":
+		updated.R = uint8(newValue)
+	case "G":
+		updated.G = uint8(newValue)
+	case "B":
+		updated.B = uint8(newValue)
+	case "A":
+		updated.A = uint8(newValue)
+	}
+
+	if s.label == "H" || s.label == "S" || s.label == "V" {
+		// Keep the HSV components as entered rather than re-deriving them,
+		// so hue survives dragging saturation or value down to zero.
+		c.color = updated
+		c.notifyChange()
+		return
+	}
+
+	c.applyColor(updated)
+}
+
+// applyColor sets the edited color and re-derives the HSV components from it.
+// Hue and saturation are kept when they cannot be recovered (grays and black).
+func (c *colorEditorController) applyColor(color sdl.Color) {
+	if !c.settings.AllowAlpha {
+		color.A = 255
+	}
+
+	c.color = color
+	h, s, v := internal.RGBToHSV(color)
+	if s > 0 && v > 0 {
+		c.hue = h
+	}
+	if v > 0 {
+		c.saturation = s
+	}
+	c.value = v
+
+	c.notifyChange()
+}
+
+func (c *colorEditorController) notifyChange() {
+	if c.settings.OnChange != nil {
+		c.settings.OnChange(c.color)
+	}
+}
+
+// syncPickerSelection points the quick-pick grid at the current color when it is one of the swatches.
+func (c *colorEditorController) syncPickerSelection() {
+	for i, swatch := range c.picker.Colors {
+		if swatch.R == c.color.R && swatch.G == c.color.G && swatch.B == c.color.B {
+			c.picker.SelectedIndex = i
+			return
+		}
+	}
+}
+
+func (c *colorEditorController) enterHex() {
+	res, err := Keyboard(internal.FormatHexColor(c.color, c.settings.AllowAlpha), "", KeyboardLayoutGeneral)
+	c.directionalInput.Reset()
+	c.lastInputTime = time.Now()
+	if err != nil {
+		return
+	}
+
+	color, err := internal.ParseHexColor(res.Text)
+	if err != nil {
+		return
+	}
+
+	c.applyColor(color)
+	c.syncPickerSelection()
+}
+
+func (c *colorEditorController) render(renderer *sdl.Renderer, window *internal.Window) {
+	renderer.SetDrawColor(0, 0, 0, 255)
+	renderer.Clear()
+
+	if window.Background != nil {
+		window.RenderBackground()
+	}
+
+	scaleFactor := internal.GetScaleFactor()
+	margins := internal.UniformPadding(20)
+	theme := internal.GetTheme()
+
+	windowWidth := window.GetWidth()
+	windowHeight := window.GetHeight()
+
+	y := margins.Top
+	if c.title != "" {
+		c.renderText(renderer, internal.Fonts.LargeFont, c.title, margins.Left, y, theme.TextColor)
+		y += int32(internal.Fonts.LargeFont.Height()) + int32(float32(10)*scaleFactor)
+	}
+
+	y = c.renderTabs(renderer, margins.Left, y) + int32(float32(20)*scaleFactor)
+
+	footerTop := windowHeight - margins.Bottom - int32(float32(70)*scaleFactor)
+	recentHeight := int32(0)
+	if len(c.recent) > 0 {
+		recentHeight = int32(float32(60) * scaleFactor)
+	}
+
+	contentWidth := windowWidth - margins.Left - margins.Right
+	previewWidth := contentWidth / 3
+	editorWidth := contentWidth - previewWidth - int32(float32(30)*scaleFactor)
+	editorHeight := footerTop - y - recentHeight
+
+	if c.tab == ColorEditorTabQuickPick {
+		c.renderQuickPick(renderer, margins.Left, y, editorWidth, editorHeight)
+	} else {
+		c.renderSliders(renderer, margins.Left, y, editorWidth)
+	}
+
+	if recentHeight > 0 {
+		c.renderRecentRow(renderer, margins.Left, footerTop-recentHeight, editorWidth, recentHeight)
+	}
+
+	c.renderPreview(renderer, windowWidth-margins.Right-previewWidth, y, previewWidth, footerTop-y)
+
+	renderStatusBar(renderer, internal.Fonts.SmallFont, c.settings.StatusBar, margins)
+
+	renderFooter(
+		renderer,
+		internal.Fonts.SmallFont,
+		[]FooterHelpItem{
+			{ButtonName: "B", HelpText: "Cancel"},
+			{ButtonName: "Y", HelpText: "Hex"},
+			{ButtonName: "A", HelpText: "Confirm"},
+		},
+		margins.Bottom,
+		true,
+		false,
+	)
+
+	window.Present()
+}
+
+// renderTabs draws the tab pills with L1/R1 hints and returns the bottom edge of the row.
+func (c *colorEditorController) renderTabs(renderer *sdl.Renderer, x, y int32) int32 {
+	scaleFactor := internal.GetScaleFactor()
+	theme := internal.GetTheme()
+	font := internal.Fonts.SmallFont
+	hintFont := internal.Fonts.TinyFont
+
+	pillPadding := int32(float32(16) * scaleFactor)
+	pillHeight := int32(font.Height()) + int32(float32(10)*scaleFactor)
+	spacing := int32(float32(10) * scaleFactor)
+
+	hintColor := sdl.Color{R: 150, G: 150, B: 150, A: 255}
+	hintY := y + (pillHeight-int32(hintFont.Height()))/2
+
+	c.renderText(renderer, hintFont, "L1", x, hintY, hintColor)
+	x += c.textWidth(hintFont, "L1") + spacing
+
+	for i, name := range colorEditorTabNames {
+		width := c.textWidth(font, name) + pillPadding*2
+		textColor := theme.TextColor
+		if ColorEditorTab(i) == c.tab {
+			internal.DrawRoundedRect(renderer, &sdl.Rect{X: x, Y: y, W: width, H: pillHeight}, pillHeight/2, theme.HighlightColor)
+			textColor = theme.HighlightedTextColor
+		}
+		c.renderText(renderer, font, name, x+pillPadding, y+(pillHeight-int32(font.Height()))/2, textColor)
+		x += width + spacing
+	}
+
+	c.renderText(renderer, hintFont, "R1", x, hintY, hintColor)
+
+	return y + pillHeight
+}
+
+func (c *colorEditorController) renderQuickPick(renderer *sdl.Renderer, x, y, width, height int32) {
+	cols := c.picker.GridCols
+	rows := c.picker.GridRows
+	if cols <= 0 || rows <= 0 {
+		return
+	}
+
+	cellSize := internal.Min32((width-c.picker.CellPadding)/cols, (height-c.picker.CellPadding)/rows) - c.picker.CellPadding
+	if cellSize <= 0 {
+		return
+	}
+
+	c.picker.CellSize = cellSize
+	c.picker.X = x + width/2
+	c.picker.Y = y + height/2
+	c.picker.Visible = true
+
+	// Dim the grid's selection while focus is on the recent row
+	selected := c.picker.SelectedIndex
+	if c.recentRowFocused() {
+		c.picker.SelectedIndex = -1
+	}
+	c.picker.drawSwatches(renderer)
+	c.picker.SelectedIndex = selected
+}
+
+func (c *colorEditorController) renderSliders(renderer *sdl.Renderer, x, y, width int32) {
+	scaleFactor := internal.GetScaleFactor()
+	theme := internal.GetTheme()
+	font := internal.Fonts.SmallFont
+
+	rowHeight := int32(float32(56) * scaleFactor)
+	labelWidth := c.textWidth(font, "W") + int32(float32(20)*scaleFactor)
+	valueWidth := c.textWidth(font, "000") + int32(float32(20)*scaleFactor)
+	trackHeight := int32(float32(18) * scaleFactor)
+	trackWidth := width - labelWidth - valueWidth
+
+	for i, s := range c.sliders() {
+		rowY := y + int32(i)*rowHeight
+
+		if i == c.focusRow {
+			internal.DrawRoundedRect(renderer, &sdl.Rect{X: x - 10, Y: rowY, W: width + 20, H: rowHeight - 6}, int32(float32(12)*scaleFactor), sdl.Color{R: 255, G: 255, B: 255, A: 40})
+		}
+
+		textY := rowY + (rowHeight-6-int32(font.Height()))/2
+		c.renderText(renderer, font, s.label, x+int32(float32(6)*scaleFactor), textY, theme.TextColor)
+		c.renderText(renderer, font, fmt.Sprintf("%d", s.value), x+labelWidth+trackWidth+int32(float32(12)*scaleFactor), textY, theme.TextColor)
+
+		track := sdl.Rect{X: x + labelWidth, Y: rowY + (rowHeight-6-trackHeight)/2, W: trackWidth, H: trackHeight}
+		c.renderSliderTrack(renderer, track, s.label)
+
+		knobX := track.X + int32(float64(track.W)*float64(s.value)/float64(s.max))
+		knobWidth := int32(float32(6) * scaleFactor)
+		knob := sdl.Rect{X: knobX - knobWidth/2, Y: track.Y - 4, W: knobWidth, H: track.H + 8}
+		renderer.SetDrawColor(255, 255, 255, 255)
+		renderer.FillRect(&knob)
+		renderer.SetDrawColor(0, 0, 0, 255)
+		renderer.DrawRect(&knob)
+	}
+}
+
+// renderSliderTrack draws the gradient a slider moves through, holding the other channels fixed.
+func (c *colorEditorController) renderSliderTrack(renderer *sdl.Renderer, track sdl.Rect, label string) {
+	if label == "A" {
+		c.renderCheckerboard(renderer, track)
+	}
+
+	renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+	const step = 2
+	for offset := int32(0); offset < track.W; offset += step {
+		t := float64(offset) / float64(track.W)
+
+		color := c.color
+		color.A = 255
+		switch label {
+		case "H":
+			color = internal.HSVToRGB(t*360, 1, 1, 255)
+		case "S":
+			color = internal.HSVToRGB(c.hue, t, c.value, 255)
+		case "V":
+			color = internal.HSVToRGB(c.hue, c.saturation, t, 255)
+		case "R":
+			color.R = uint8(t * 255)
+		case "G":
+			color.G = uint8(t * 255)
+		case "B":
+			color.B = uint8(t * 255)
+		case "A":
+			color.A = uint8(t * 255)
+		}
+
+		renderer.SetDrawColor(color.R, color.G, color.B, color.A)
+		renderer.FillRect(&sdl.Rect{X: track.X + offset, Y: track.Y, W: internal.Min32(step, track.W-offset), H: track.H})
+	}
+}
+
+func (c *colorEditorController) renderRecentRow(renderer *sdl.Renderer, x, y, width, height int32) {
+	scaleFactor := internal.GetScaleFactor()
+	font := internal.Fonts.TinyFont
+
+	c.renderText(renderer, font, "Recent", x, y+(height-int32(font.Height()))/2, internal.GetTheme().TextColor)
+
+	swatchSize := height - int32(float32(20)*scaleFactor)
+	spacing := int32(float32(10) * scaleFactor)
+	swatchX := x + c.textWidth(font, "Recent") + spacing*2
+	swatchY := y + (height-swatchSize)/2
+	focused := c.recentRowFocused()
+
+	for i, color := range c.recent {
+		if swatchX+swatchSize > x+width {
+			break
+		}
+
+		rect := sdl.Rect{X: swatchX, Y: swatchY, W: swatchSize, H: swatchSize}
+		if focused && i == c.recentIndex {
+			renderer.SetDrawColor(255, 255, 255, 255)
+			renderer.FillRect(&sdl.Rect{X: rect.X - 3, Y: rect.Y - 3, W: rect.W + 6, H: rect.H + 6})
+		}
+		c.renderCheckerboard(renderer, rect)
+		renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+		renderer.SetDrawColor(color.R, color.G, color.B, color.A)
+		renderer.FillRect(&rect)
+
+		swatchX += swatchSize + spacing
+	}
+}
+
+// renderPreview draws the edited color above the original one, with the hex value underneath.
+func (c *colorEditorController) renderPreview(renderer *sdl.Renderer, x, y, width, height int32) {
+	font := internal.Fonts.SmallFont
+	textHeight := int32(font.Height())
+
+	size := internal.Min32(width, height-textHeight*2)
+	if size <= 0 {
+		return
+	}
+
+	swatch := sdl.Rect{X: x + (width-size)/2, Y: y, W: size, H: size}
+	c.renderCheckerboard(renderer, swatch)
+
+	renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+	renderer.SetDrawColor(c.color.R, c.color.G, c.color.B, c.color.A)
+	renderer.FillRect(&sdl.Rect{X: swatch.X, Y: swatch.Y, W: swatch.W, H: swatch.H * 3 / 4})
+	renderer.SetDrawColor(c.initial.R, c.initial.G, c.initial.B, c.initial.A)
+	renderer.FillRect(&sdl.Rect{X: swatch.X, Y: swatch.Y + swatch.H*3/4, W: swatch.W, H: swatch.H - swatch.H*3/4})
+
+	renderer.SetDrawColor(200, 200, 200, 255)
+	renderer.DrawRect(&swatch)
+
+	hex := internal.FormatHexColor(c.color, c.settings.AllowAlpha)
+	hexWidth := c.textWidth(font, hex)
+	c.renderText(renderer, font, hex, x+(width-hexWidth)/2, swatch.Y+swatch.H+textHeight/2, internal.GetTheme().TextColor)
+}
+
+// renderCheckerboard fills rect with a light checker pattern so translucent colors read correctly.
+func (c *colorEditorController) renderCheckerboard(renderer *sdl.Renderer, rect sdl.Rect) {
+	cell := int32(float32(8) * internal.GetScaleFactor())
+	if cell <= 0 {
+		cell = 8
+	}
+
+	for row := int32(0); row*cell < rect.H; row++ {
+		for col := int32(0); col*cell < rect.W; col++ {
+			if (row+col)%2 == 0 {
+				renderer.SetDrawColor(255, 255, 255, 255)
+			} else {
+				renderer.SetDrawColor(200, 200, 200, 255)
+			}
+			renderer.FillRect(&sdl.Rect{
+				X: rect.X + col*cell,
+				Y: rect.Y + row*cell,
+				W: internal.Min32(cell, rect.W-col*cell),
+				H: internal.Min32(cell, rect.H-row*cell),
+			})
+		}
+	}
+}
+
+func (c *colorEditorController) textWidth(font *ttf.Font, text string) int32 {
+	width, _, err := font.SizeUTF8(text)
+	if err != nil {
+		return 0
+	}
+	return int32(width)
+}
+
+func (c *colorEditorController) renderText(renderer *sdl.Renderer, font *ttf.Font, text string, x, y int32, color sdl.Color) {
+	surface, err := font.RenderUTF8Blended(text, color)
+	if err != nil {
+		return
+	}
+	defer surface.Free()
+
+	texture, err := renderer.CreateTextureFromSurface(surface)
+	if err != nil {
+		return
+	}
+	defer texture.Destroy()
+
+	renderer.Copy(texture, nil, &sdl.Rect{X: x, Y: y, W: surface.W, H: surface.H})
+}
@@ -93,6 +93,13 @@ func (h *ColorPicker) draw(renderer *sdl.Renderer) {
 		return
 	}
 
+	h.drawSwatches(renderer)
+	renderStatusBar(renderer, internal.Fonts.SmallFont, h.StatusBar, internal.UniformPadding(20))
+}
+
+// drawSwatches draws the color grid and its selection, without the status bar, for
+// screens that embed the picker.
+func (h *ColorPicker) drawSwatches(renderer *sdl.Renderer) {
 	startX := h.X - (h.GridCols*(h.CellSize+h.CellPadding))/2
 	startY := h.Y - (h.GridRows*(h.CellSize+h.CellPadding))/2
 
@@ -180,8 +187,6 @@ func (h *ColorPicker) draw(renderer *sdl.Renderer) {
 			renderer.FillRect(&cellRect)
 		}
 	}
-
-	renderStatusBar(renderer, internal.Fonts.SmallFont, h.StatusBar, internal.UniformPadding(20))
 }
 
 func (h *ColorPicker) handleKeyPress(key sdl.Keycode) bool {
@@ -12,6 +12,8 @@ import (
 // MessageOptions configures the confirmation message dialog.
 type MessageOptions struct {
 	ImagePath     string                  // Optional image to display above the message
+	QRCode        string                  // Optional text shown as a QR code in place of the image
+	QRCodeLevel   QRCodeErrorCorrection   // Error correction for QRCode
 	ConfirmButton constants.VirtualButton // Button to confirm (default: A)
 	CancelButton  constants.VirtualButton // Button to cancel (default: B)
 	StatusBar     StatusBarOptions        // Status icons configuration
@@ -30,6 +32,8 @@ type confirmationMessageSettings struct {
 	ConfirmButton   constants.VirtualButton
 	CancelButton    constants.VirtualButton
 	ImagePath       string
+	QRCode          string
+	QRCodeLevel     QRCodeErrorCorrection
 	MaxImageHeight  int32
 	MaxImageWidth   int32
 	BackgroundColor sdl.Color
@@ -67,8 +71,10 @@ func ConfirmationMessage(message string, footerHelpItems []FooterHelpItem, optio
 	settings := defaultMessageSettings(message)
 	settings.FooterHelpItems = footerHelpItems
 
-	if options.ImagePath != "" {
+	if options.ImagePath != "" || options.QRCode != "" {
 		settings.ImagePath = options.ImagePath
+		settings.QRCode = options.QRCode
+		settings.QRCodeLevel = options.QRCodeLevel
 		settings.MaxImageWidth = int32(float64(window.GetWidth()) / 1.75)
 		settings.MaxImageHeight = int32(float64(window.GetHeight()) / 1.75)
 	}
@@ -108,6 +114,15 @@ func ConfirmationMessage(message string, footerHelpItems []FooterHelpItem, optio
 }
 
 func loadAndPrepareImage(renderer *sdl.Renderer, settings confirmationMessageSettings) (*sdl.Texture, sdl.Rect) {
+	if settings.QRCode != "" {
+		texture, rect, err := createQRCodeTexture(renderer, settings.QRCode, settings.QRCodeLevel, internal.Min32(settings.MaxImageWidth, settings.MaxImageHeight))
+		if err != nil {
+			internal.GetInternalLogger().Error("Failed to create QR code", "error", err)
+			return nil, sdl.Rect{}
+		}
+		return texture, rect
+	}
+
 	if settings.ImagePath == "" {
 		return nil, sdl.Rect{}
 	}
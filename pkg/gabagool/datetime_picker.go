@@ -0,0 +1,578 @@
+package gabagool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/i18n"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// DateTimePickerSettings configures the date, time and duration pickers.
+type DateTimePickerSettings struct {
+	// TimeFormat selects a 12 or 24-hour clock for the time picker (default: 24-hour).
+	// Set TimeFormatLocale to follow the current language.
+	TimeFormat TimeFormat
+	// ShowSeconds adds a seconds field to the time and duration pickers
+	ShowSeconds bool
+	// MinYear and MaxYear bound the year field of the date picker (default: 1970 to 2099)
+	MinYear int
+	MaxYear int
+	// MaxDurationHours caps the hours field of the duration picker (default: 99)
+	MaxDurationHours int
+	// FooterHelpItems overrides the default confirm/cancel footer
+	FooterHelpItems []FooterHelpItem
+	// StatusBar configures the optional status bar in the top-right corner
+	StatusBar StatusBarOptions
+}
+
+// DatePickerResult represents the result of a date picker.
+type DatePickerResult struct {
+	// Date is the chosen date. The time of day and location are carried over from the initial value.
+	Date time.Time
+}
+
+// TimePickerResult represents the result of a time picker.
+type TimePickerResult struct {
+	// Time is the chosen time of day. The date and location are carried over from the initial value.
+	Time time.Time
+}
+
+// DurationPickerResult represents the result of a duration picker.
+type DurationPickerResult struct {
+	// Duration is the chosen duration
+	Duration time.Duration
+}
+
+type spinnerFieldKind int
+
+const (
+	spinnerFieldYear spinnerFieldKind = iota
+	spinnerFieldMonth
+	spinnerFieldDay
+	spinnerFieldHour
+	spinnerFieldMinute
+	spinnerFieldSecond
+	spinnerFieldMeridiem
+)
+
+// spinnerField is a single D-pad adjustable segment of a picker.
+type spinnerField struct {
+	kind      spinnerFieldKind
+	label     string
+	value     int
+	min       int
+	max       int
+	digits    int
+	separator string // drawn after this field
+}
+
+func (f *spinnerField) text() string {
+	if f.kind == spinnerFieldMeridiem {
+		if f.value == 0 {
+			return "AM"
+		}
+		return "PM"
+	}
+	return fmt.Sprintf("%0*d", f.digits, f.value)
+}
+
+type dateTimePickerController struct {
+	title            string
+	fields           []spinnerField
+	focused          int
+	settings         DateTimePickerSettings
+	inputDelay       time.Duration
+	lastInputTime    time.Time
+	directionalInput internal.DirectionalInput
+	confirmed        bool
+	cancelled        bool
+}
+
+func defaultDateTimePickerSettings(settings DateTimePickerSettings) DateTimePickerSettings {
+	if settings.MinYear == 0 {
+		settings.MinYear = 1970
+	}
+	if settings.MaxYear == 0 || settings.MaxYear < settings.MinYear {
+		settings.MaxYear = 2099
+	}
+	if settings.MaxDurationHours <= 0 {
+		settings.MaxDurationHours = 99
+	}
+	if len(settings.FooterHelpItems) == 0 {
+		settings.FooterHelpItems = []FooterHelpItem{
+			{ButtonName: "B", HelpText: "Cancel"},
+			{ButtonName: "A", HelpText: "Confirm"},
+		}
+	}
+	return settings
+}
+
+func newDateTimePickerController(title string, fields []spinnerField, settings DateTimePickerSettings) *dateTimePickerController {
+	return &dateTimePickerController{
+		title:            title,
+		fields:           fields,
+		settings:         settings,
+		inputDelay:       constants.DefaultInputDelay,
+		lastInputTime:    time.Now(),
+		directionalInput: internal.NewDirectionalInputWithTiming(300*time.Millisecond, 60*time.Millisecond),
+	}
+}
+
+// DatePicker displays a segmented day/month/year spinner. Fields are ordered
+// according to the current i18n language. Up/down changes the focused field
+// and left/right moves between fields. A zero initial value starts from today.
+// Returns ErrCancelled if the user presses B.
+func DatePicker(title string, initial time.Time, settings DateTimePickerSettings) (*DatePickerResult, error) {
+	settings = defaultDateTimePickerSettings(settings)
+	if initial.IsZero() {
+		initial = time.Now()
+	}
+
+	c := newDateTimePickerController(title, dateFields(initial, settings), settings)
+	if err := c.run(); err != nil {
+		return nil, err
+	}
+
+	return &DatePickerResult{Date: c.dateValue(initial)}, nil
+}
+
+// TimePicker displays a segmented hour/minute spinner, with optional seconds
+// and an AM/PM field when a 12-hour clock is in use.
+// Returns ErrCancelled if the user presses B.
+func TimePicker(title string, initial time.Time, settings DateTimePickerSettings) (*TimePickerResult, error) {
+	settings = defaultDateTimePickerSettings(settings)
+	if initial.IsZero() {
+		initial = time.Now()
+	}
+
+	c := newDateTimePickerController(title, timeFields(initial, settings), settings)
+	if err := c.run(); err != nil {
+		return nil, err
+	}
+
+	return &TimePickerResult{Time: c.timeValue(initial)}, nil
+}
+
+// DurationPicker displays a segmented hours/minutes spinner, with optional seconds.
+// Returns ErrCancelled if the user presses B.
+func DurationPicker(title string, initial time.Duration, settings DateTimePickerSettings) (*DurationPickerResult, error) {
+	settings = defaultDateTimePickerSettings(settings)
+
+	c := newDateTimePickerController(title, durationFields(initial, settings), settings)
+	if err := c.run(); err != nil {
+		return nil, err
+	}
+
+	return &DurationPickerResult{Duration: c.durationValue()}, nil
+}
+
+func dateFields(t time.Time, settings DateTimePickerSettings) []spinnerField {
+	year := t.Year()
+	if year < settings.MinYear {
+		year = settings.MinYear
+	} else if year > settings.MaxYear {
+		year = settings.MaxYear
+	}
+
+	yearField := spinnerField{kind: spinnerFieldYear, label: "Year", value: year, min: settings.MinYear, max: settings.MaxYear, digits: 4}
+	monthField := spinnerField{kind: spinnerFieldMonth, label: "Month", value: int(t.Month()), min: 1, max: 12, digits: 2}
+	dayField := spinnerField{kind: spinnerFieldDay, label: "Day", value: t.Day(), min: 1, max: internal.DaysInMonth(year, t.Month()), digits: 2}
+	if dayField.value > dayField.max {
+		dayField.value = dayField.max
+	}
+
+	format := internal.DateFormatForLanguage(i18n.CurrentLanguage())
+
+	var fields []spinnerField
+	switch format.Order {
+	case internal.DateOrderMDY:
+		fields = []spinnerField{monthField, dayField, yearField}
+	case internal.DateOrderYMD:
+		fields = []spinnerField{yearField, monthField, dayField}
+	default:
+		fields = []spinnerField{dayField, monthField, yearField}
+	}
+
+	fields[0].separator = format.Separator
+	fields[1].separator = format.Separator
+
+	return fields
+}
+
+func timeFields(t time.Time, settings DateTimePickerSettings) []spinnerField {
+	twelveHour := resolveTimeFormat(settings.TimeFormat) == TimeFormat12Hour
+
+	hourField := spinnerField{kind: spinnerFieldHour, label: "Hour", value: t.Hour(), min: 0, max: 23, digits: 2, separator: ":"}
+	if twelveHour {
+		hourField.value = t.Hour() % 12
+		if hourField.value == 0 {
+			hourField.value = 12
+		}
+		hourField.min = 1
+		hourField.max = 12
+	}
+
+	fields := []spinnerField{
+		hourField,
+		{kind: spinnerFieldMinute, label: "Min", value: t.Minute(), min: 0, max: 59, digits: 2},
+	}
+
+	if settings.ShowSeconds {
+		fields[1].separator = ":"
+		fields = append(fields, spinnerField{kind: spinnerFieldSecond, label: "Sec", value: t.Second(), min: 0, max: 59, digits: 2})
+	}
+
+	if twelveHour {
+		meridiem := 0
+		if t.Hour() >= 12 {
+			meridiem = 1
+		}
+		fields = append(fields, spinnerField{kind: spinnerFieldMeridiem, value: meridiem, min: 0, max: 1})
+	}
+
+	return fields
+}
+
+func durationFields(d time.Duration, settings DateTimePickerSettings) []spinnerField {
+	if d < 0 {
+		d = 0
+	}
+
+	hours := int(d / time.Hour)
+	if hours > settings.MaxDurationHours {
+		hours = settings.MaxDurationHours
+	}
+
+	fields := []spinnerField{
+		{kind: spinnerFieldHour, label: "Hours", value: hours, min: 0, max: settings.MaxDurationHours, digits: 2, separator: ":"},
+		{kind: spinnerFieldMinute, label: "Min", value: int(d/time.Minute) % 60, min: 0, max: 59, digits: 2},
+	}
+
+	if settings.ShowSeconds {
+		fields[1].separator = ":"
+		fields = append(fields, spinnerField{kind: spinnerFieldSecond, label: "Sec", value: int(d/time.Second) % 60, min: 0, max: 59, digits: 2})
+	}
+
+	return fields
+}
+
+// field returns the value of the first field of the given kind, or fallback if there is none.
+func (c *dateTimePickerController) field(kind spinnerFieldKind, fallback int) int {
+	for i := range c.fields {
+		if c.fields[i].kind == kind {
+			return c.fields[i].value
+		}
+	}
+	return fallback
+}
+
+func (c *dateTimePickerController) dateValue(base time.Time) time.Time {
+	return time.Date(
+		c.field(spinnerFieldYear, base.Year()),
+		time.Month(c.field(spinnerFieldMonth, int(base.Month()))),
+		c.field(spinnerFieldDay, base.Day()),
+		base.Hour(), base.Minute(), base.Second(), base.Nanosecond(),
+		base.Location(),
+	)
+}
+
+func (c *dateTimePickerController) timeValue(base time.Time) time.Time {
+	hour := c.field(spinnerFieldHour, base.Hour())
+	if meridiem := c.field(spinnerFieldMeridiem, -1); meridiem >= 0 {
+		hour = hour%12 + meridiem*12
+	}
+
+	return time.Date(
+		base.Year(), base.Month(), base.Day(),
+		hour,
+		c.field(spinnerFieldMinute, 0),
+		c.field(spinnerFieldSecond, 0),
+		0,
+		base.Location(),
+	)
+}
+
+func (c *dateTimePickerController) durationValue() time.Duration {
+	return time.Duration(c.field(spinnerFieldHour, 0))*time.Hour +
+		time.Duration(c.field(spinnerFieldMinute, 0))*time.Minute +
+		time.Duration(c.field(spinnerFieldSecond, 0))*time.Second
+}
+
+func (c *dateTimePickerController) run() error {
+	window := internal.GetWindow()
+	renderer := window.Renderer
+
+	for !c.confirmed && !c.cancelled {
+		if err := c.handleEvents(); err != nil {
+			return err
+		}
+
+		switch c.directionalInput.Update() {
+		case internal.DirectionUp:
+			c.adjust(1)
+		case internal.DirectionDown:
+			c.adjust(-1)
+		case internal.DirectionLeft:
+			c.moveFocus(-1)
+		case internal.DirectionRight:
+			c.moveFocus(1)
+		}
+
+		c.render(renderer, window)
+	}
+
+	if c.cancelled {
+		return ErrCancelled
+	}
+
+	return nil
+}
+
+func (c *dateTimePickerController) handleEvents() error {
+	processor := internal.GetInputProcessor()
+
+	event := sdl.WaitEventTimeout(16)
+	if event == nil {
+		return nil
+	}
+
+	switch event.(type) {
+	case *sdl.QuitEvent:
+		c.cancelled = true
+		return sdl.GetError()
+
+	case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.ControllerAxisEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent:
+		inputEvent := processor.ProcessSDLEvent(event.(sdl.Event))
+		if inputEvent == nil {
+			return nil
+		}
+
+		if !inputEvent.Pressed {
+			c.directionalInput.SetHeld(inputEvent.Button, false)
+			return nil
+		}
+
+		if time.Since(c.lastInputTime) < c.inputDelay {
+			return nil
+		}
+		c.lastInputTime = time.Now()
+
+		switch inputEvent.Button {
+		case constants.VirtualButtonUp:
+			c.directionalInput.SetHeld(inputEvent.Button, true)
+			c.adjust(1)
+		case constants.VirtualButtonDown:
+			c.directionalInput.SetHeld(inputEvent.Button, true)
+			c.adjust(-1)
+		case constants.VirtualButtonLeft:
+			c.directionalInput.SetHeld(inputEvent.Button, true)
+			c.moveFocus(-1)
+		case constants.VirtualButtonRight:
+			c.directionalInput.SetHeld(inputEvent.Button, true)
+			c.moveFocus(1)
+		case constants.VirtualButtonA, constants.VirtualButtonStart:
+			c.confirmed = true
+		case constants.VirtualButtonB:
+			c.cancelled = true
+		}
+	}
+
+	return nil
+}
+
+func (c *dateTimePickerController) moveFocus(delta int) {
+	c.focused = internal.WrapInt(c.focused+delta, 0, len(c.fields)-1)
+}
+
+func (c *dateTimePickerController) adjust(delta int) {
+	f := &c.fields[c.focused]
+	f.value = internal.WrapInt(f.value+delta, f.min, f.max)
+	c.updateDayRange()
+}
+
+// updateDayRange keeps the day field within the length of the selected month.
+func (c *dateTimePickerController) updateDayRange() {
+	year := c.field(spinnerFieldYear, -1)
+	month := c.field(spinnerFieldMonth, -1)
+	if year < 0 || month < 0 {
+		return
+	}
+
+	for i := range c.fields {
+		if c.fields[i].kind != spinnerFieldDay {
+			continue
+		}
+		c.fields[i].max = internal.DaysInMonth(year, time.Month(month))
+		if c.fields[i].value > c.fields[i].max {
+			c.fields[i].value = c.fields[i].max
+		}
+	}
+}
+
+func (c *dateTimePickerController) render(renderer *sdl.Renderer, window *internal.Window) {
+	renderer.SetDrawColor(0, 0, 0, 255)
+	renderer.Clear()
+
+	if window.Background != nil {
+		window.RenderBackground()
+	}
+
+	scaleFactor := internal.GetScaleFactor()
+	theme := internal.GetTheme()
+	margins := internal.UniformPadding(20)
+
+	titleFont := internal.Fonts.LargeFont
+	valueFont := internal.Fonts.ExtraLargeFont
+	labelFont := internal.Fonts.TinyFont
+
+	windowWidth := window.GetWidth()
+	windowHeight := window.GetHeight()
+
+	if c.title != "" {
+		titleWidth := c.textWidth(titleFont, c.title)
+		c.renderText(renderer, titleFont, c.title, (windowWidth-titleWidth)/2, margins.Top+int32(float32(20)*scaleFactor), theme.TextColor)
+	}
+
+	cellPadding := int32(float32(16) * scaleFactor)
+	arrowSize := int32(float32(12) * scaleFactor)
+	arrowGap := int32(float32(14) * scaleFactor)
+	labelGap := int32(float32(10) * scaleFactor)
+
+	valueHeight := int32(valueFont.Height())
+	cellHeight := valueHeight + cellPadding
+	labelHeight := int32(labelFont.Height())
+
+	// Measure every column up front so the row can be centered
+	cellWidths := make([]int32, len(c.fields))
+	separatorWidths := make([]int32, len(c.fields))
+	var totalWidth int32
+	for i := range c.fields {
+		widest := "00"
+		switch c.fields[i].kind {
+		case spinnerFieldYear:
+			widest = "0000"
+		case spinnerFieldMeridiem:
+			widest = "PM"
+		}
+		cellWidths[i] = internal.Max32(c.textWidth(valueFont, widest), c.textWidth(labelFont, c.fields[i].label)) + cellPadding*2
+		if c.fields[i].separator != "" {
+			separatorWidths[i] = c.textWidth(valueFont, c.fields[i].separator) + cellPadding/2
+		}
+		totalWidth += cellWidths[i] + separatorWidths[i]
+	}
+
+	blockHeight := labelHeight + labelGap + arrowSize + arrowGap + cellHeight + arrowGap + arrowSize
+	startY := (windowHeight - blockHeight) / 2
+	cellY := startY + labelHeight + labelGap + arrowSize + arrowGap
+
+	x := (windowWidth - totalWidth) / 2
+	for i := range c.fields {
+		f := &c.fields[i]
+		focused := i == c.focused
+		centerX := x + cellWidths[i]/2
+
+		if f.label != "" {
+			labelWidth := c.textWidth(labelFont, f.label)
+			c.renderText(renderer, labelFont, f.label, centerX-labelWidth/2, startY, theme.TextColor)
+		}
+
+		textColor := theme.TextColor
+		if focused {
+			internal.DrawRoundedRect(renderer, &sdl.Rect{X: x, Y: cellY, W: cellWidths[i], H: cellHeight}, int32(float32(12)*scaleFactor), theme.HighlightColor)
+			textColor = theme.HighlightedTextColor
+
+			arrowColor := theme.HighlightColor
+			upY := cellY - arrowGap
+			internal.DrawFilledTriangle(renderer, centerX-arrowSize, upY, centerX+arrowSize, upY, centerX, upY-arrowSize, arrowColor)
+			downY := cellY + cellHeight + arrowGap
+			internal.DrawFilledTriangle(renderer, centerX-arrowSize, downY, centerX+arrowSize, downY, centerX, downY+arrowSize, arrowColor)
+		} else {
+			internal.DrawRoundedRect(renderer, &sdl.Rect{X: x, Y: cellY, W: cellWidths[i], H: cellHeight}, int32(float32(12)*scaleFactor), sdl.Color{R: 50, G: 50, B: 50, A: 255})
+		}
+
+		value := f.text()
+		valueWidth := c.textWidth(valueFont, value)
+		c.renderText(renderer, valueFont, value, centerX-valueWidth/2, cellY+(cellHeight-valueHeight)/2, textColor)
+
+		x += cellWidths[i]
+
+		if f.separator != "" {
+			sepWidth := c.textWidth(valueFont, f.separator)
+			c.renderText(renderer, valueFont, f.separator, x+(separatorWidths[i]-sepWidth)/2, cellY+(cellHeight-valueHeight)/2, theme.TextColor)
+			x += separatorWidths[i]
+		}
+	}
+
+	renderStatusBar(renderer, internal.Fonts.SmallFont, c.settings.StatusBar, margins)
+
+	renderFooter(
+		renderer,
+		internal.Fonts.SmallFont,
+		c.settings.FooterHelpItems,
+		margins.Bottom,
+		true,
+		false,
+	)
+
+	window.Present()
+}
+
+func (c *dateTimePickerController) textWidth(font *ttf.Font, text string) int32 {
+	width, _, err := font.SizeUTF8(text)
+	if err != nil {
+		return 0
+	}
+	return int32(width)
+}
+
+func (c *dateTimePickerController) renderText(renderer *sdl.Renderer, font *ttf.Font, text string, x, y int32, color sdl.Color) {
+	surface, err := font.RenderUTF8Blended(text, color)
+	if err != nil {
+		return
+	}
+	defer surface.Free()
+
+	texture, err := renderer.CreateTextureFromSurface(surface)
+	if err != nil {
+		return
+	}
+	defer texture.Destroy()
+
+	renderer.Copy(texture, nil, &sdl.Rect{X: x, Y: y, W: surface.W, H: surface.H})
+}
+
+// formatDate formats t as a numeric date in the current language's convention.
+func formatDate(t time.Time) string {
+	return internal.DateFormatForLanguage(i18n.CurrentLanguage()).Format(t)
+}
+
+// formatTimeOfDay formats t as a clock time in the given format.
+func formatTimeOfDay(t time.Time, format TimeFormat, showSeconds bool) string {
+	if resolveTimeFormat(format) == TimeFormat12Hour {
+		if showSeconds {
+			return t.Format("3:04:05 PM")
+		}
+		return t.Format("3:04 PM")
+	}
+	if showSeconds {
+		return t.Format("15:04:05")
+	}
+	return t.Format("15:04")
+}
+
+// formatDuration formats d as hours and minutes, e.g. "1:30" or "1:30:05" with seconds.
+func formatDuration(d time.Duration, showSeconds bool) string {
+	if d < 0 {
+		d = 0
+	}
+	hours := int(d / time.Hour)
+	minutes := int(d/time.Minute) % 60
+	if showSeconds {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, int(d/time.Second)%60)
+	}
+	return fmt.Sprintf("%d:%02d", hours, minutes)
+}
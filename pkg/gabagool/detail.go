@@ -27,6 +27,11 @@ const (
 	SectionTypeImage              // Single static image
 	SectionTypeDropdown           // Interactive dropdown selector
 	SectionTypeTable              // Table with rows and optional headers
+	SectionTypeMarkdown           // Markdown formatted text (CommonMark subset)
+	SectionTypeQRCode             // QR code with optional caption
+	SectionTypeButtons            // Row of focusable buttons
+	SectionTypeCustom             // Drawn by an application-provided CustomSection
+	SectionTypeChart              // Bar, line or sparkline chart
 )
 
 // Table grid style constants.
@@ -54,7 +59,7 @@ type Section struct {
 	Title           string                      // Section header text
 	ImagePaths      []string                    // Image paths for slideshow/image sections
 	Metadata        []MetadataItem              // Key-value pairs for info sections
-	Description     string                      // Text content for description sections
+	Description     string                      // Text content for description and markdown sections
 	MaxWidth        int32                       // Maximum image width (0 = auto)
 	MaxHeight       int32                       // Maximum image height (0 = auto)
 	Alignment       int                         // Image alignment (cast from constants.TextAlign)
@@ -65,6 +70,24 @@ type Section struct {
 	TableHeaders    []string                    // Optional column headers for table sections
 	TableRows       []TableRow                  // Row data for table sections
 	TableGrid       int                         // Grid style constant (default: TableGridNone)
+	TableColumns    []TableColumn               // Optional per-column width, alignment and sorting
+	TableID         string                      // Makes table rows focusable and selectable; identifies the selection
+	QRCodeData      string                      // Text encoded by QR code sections
+	QRCodeLevel     QRCodeErrorCorrection       // Error correction for QR code sections
+	Caption         string                      // Text shown below QR code sections
+	ImageCaptions   []string                    // Per-image captions for slideshow and image sections
+	SlideInterval   time.Duration               // Auto-advance interval for slideshow sections (0 = manual only)
+	SlideCrossfade  time.Duration               // Crossfade between slideshow images (0 = cut)
+	Buttons         []DetailButton              // Buttons for buttons sections
+	Collapsible     bool                        // Focusable header that shows and hides the content
+	Collapsed       bool                        // Initial state of collapsible sections
+	SectionID       string                      // Identifies collapsible sections in DetailScreenResult
+	Custom          CustomSection               // Measures and draws custom sections
+	ChartType       ChartType                   // Drawing style for chart sections
+	ChartLabels     []string                    // Category labels along the x axis of chart sections
+	ChartSeries     []ChartSeries               // Data for chart sections
+	ChartHeight     int32                       // Chart height (0 = default for the chart type)
+	ChartShowValues bool                        // Show values on the bars and points of chart sections
 }
 
 // DetailScreenOptions configures the appearance and behavior of a DetailScreen.
@@ -77,6 +100,7 @@ type DetailScreenOptions struct {
 	AllowAction         bool                    // Enable action button (Y)
 	ActionButton        constants.VirtualButton // Button for primary action
 	ConfirmButton       constants.VirtualButton // Button to confirm and exit
+	ImageViewerButton   constants.VirtualButton // Opens the slideshow or image on screen in an ImageViewer (unassigned = disabled)
 	MaxImageHeight      int32                   // Default max image height
 	MaxImageWidth       int32                   // Default max image width
 	ShowScrollbar       bool                    // Display scrollbar when content overflows
@@ -95,6 +119,9 @@ type DropdownSelection struct {
 type DetailScreenResult struct {
 	Action             DetailAction
 	DropdownSelections []DropdownSelection
+	ButtonID           string // ID of the activated button when Action is DetailActionButtonPressed
+	TableSelections    []TableSelection
+	SectionExpansions  []SectionExpansion
 }
 
 type detailScreenState struct {
@@ -108,24 +135,41 @@ type detailScreenState struct {
 	scrollAnimationSpeed  float32
 	lastInputTime         time.Time
 	inputDelay            time.Duration
-	slideshowStates       map[int]slideshowState
+	slideshowStates       map[int]*slideshowState
 	dropdownStates        map[string]*dropdownState
+	tableStates           map[string]*tableState
+	sectionExpansions     map[int]*sectionExpansionState
 	focusedDropdownID     string
+	focusedButtonSection  int // buttons section with a focused button, -1 = none
+	focusedButton         int // index of the focused button in that section
+	focusedTableID        string
+	focusedTableRow       int
+	focusedHeader         int // collapsible section whose header has focus, -1 = none
+	focusedCustom         int // custom section with focus, -1 = none
 	visibleDropdownID     string
+	focusables            []detailFocusable // dropdowns and buttons in page order, from the last render
+	viewportHeight        int32
 	textureCache          *internal.TextureCache
 	titleTexture          *sdl.Texture
 	sectionTitleTextures  []*sdl.Texture
 	metadataLabelTextures map[int][]*sdl.Texture
+	markdownLayouts       map[int]*markdownLayout
 	directionalInput      internal.DirectionalInput
 	result                DetailScreenResult
 	activeSlideshow       int
+	visibleImageSection   int     // slideshow or image section on screen for the image viewer, -1 = none
 	sectionOffsets        []int32 // absolute Y offset of each section (scroll-independent)
 }
 
 type slideshowState struct {
-	currentIndex int
-	textures     []*sdl.Texture
-	dimensions   []sdl.Rect
+	currentIndex    int
+	slides          []slideshowSlide
+	maxWidth        int32
+	maxHeight       int32
+	visible         bool      // on screen in the last render
+	lastAdvance     time.Time // when the slideshow last changed slide, for auto-advance
+	previousIndex   int       // slide fading out, -1 = none
+	transitionStart time.Time
 }
 
 type dropdownState struct {
@@ -212,6 +256,27 @@ func NewTableSection(title string, headers []string, rows []TableRow, gridStyle
 	}
 }
 
+// NewMarkdownSection creates a text section formatted with Markdown: headings, lists,
+// emphasis, inline code, code blocks, block quotes and horizontal rules.
+func NewMarkdownSection(title string, markdown string) Section {
+	return Section{
+		Type:        SectionTypeMarkdown,
+		Title:       title,
+		Description: markdown,
+	}
+}
+
+// NewQRCodeSection creates a section showing data as a QR code, such as a URL or
+// pairing code to scan with a phone. The caption is shown below it and may be empty.
+func NewQRCodeSection(title string, data string, caption string) Section {
+	return Section{
+		Type:       SectionTypeQRCode,
+		Title:      title,
+		QRCodeData: data,
+		Caption:    caption,
+	}
+}
+
 // DetailScreen displays a scrollable detail screen with sections.
 func DetailScreen(title string, options DetailScreenOptions, footerHelpItems []FooterHelpItem) (*DetailScreenResult, error) {
 	state := initializeDetailScreenState(title, options, footerHelpItems)
@@ -224,6 +289,8 @@ func DetailScreen(title string, options DetailScreenOptions, footerHelpItems []F
 	}
 
 	state.collectDropdownSelections()
+	state.collectTableSelections()
+	state.collectSectionExpansions()
 
 	if state.result.Action == DetailActionCancelled {
 		return nil, ErrCancelled
@@ -257,18 +324,27 @@ func initializeDetailScreenState(title string, options DetailScreenOptions, foot
 		scrollAnimationSpeed:  0.15,
 		lastInputTime:         time.Now(),
 		inputDelay:            constants.DefaultInputDelay,
-		slideshowStates:       make(map[int]slideshowState),
+		slideshowStates:       make(map[int]*slideshowState),
 		dropdownStates:        make(map[string]*dropdownState),
+		tableStates:           make(map[string]*tableState),
+		sectionExpansions:     make(map[int]*sectionExpansionState),
 		textureCache:          internal.NewTextureCache(),
 		metadataLabelTextures: make(map[int][]*sdl.Texture),
+		markdownLayouts:       make(map[int]*markdownLayout),
 		directionalInput:      internal.NewDirectionalInputWithTiming(150*time.Millisecond, 50*time.Millisecond),
 		result:                DetailScreenResult{Action: DetailActionNone},
+		visibleImageSection:   -1,
+		focusedButtonSection:  -1,
+		focusedHeader:         -1,
+		focusedCustom:         -1,
 	}
 
 	state.initializeImageDefaults()
 	state.loadTextures(title)
 	state.initializeSlideshows()
 	state.initializeDropdowns()
+	state.initializeTables()
+	state.initializeSectionExpansions()
 
 	return state
 }
@@ -309,9 +385,11 @@ func (s *detailScreenState) loadTextures(title string) {
 
 func (s *detailScreenState) initializeSlideshows() {
 	for i, section := range s.options.Sections {
-		if section.Type == SectionTypeSlideshow || section.Type == SectionTypeImage {
-			state := s.createSlideshowState(section)
-			if len(state.textures) > 0 {
+		switch section.Type {
+		case SectionTypeSlideshow, SectionTypeImage:
+			s.slideshowStates[i] = s.createSlideshowState(section)
+		case SectionTypeQRCode:
+			if state := s.createQRCodeState(section); state != nil {
 				s.slideshowStates[i] = state
 			}
 		}
@@ -334,7 +412,9 @@ func (s *detailScreenState) initializeDropdowns() {
 	}
 }
 
-func (s *detailScreenState) createSlideshowState(section Section) slideshowState {
+// createSlideshowState sets up the slides of a slideshow or image section. Images are
+// loaded later, as the section nears the screen, so long slideshows don't hold up startup.
+func (s *detailScreenState) createSlideshowState(section Section) *slideshowState {
 	maxWidth := section.MaxWidth
 	maxHeight := section.MaxHeight
 	if maxWidth == 0 {
@@ -344,26 +424,55 @@ func (s *detailScreenState) createSlideshowState(section Section) slideshowState
 		maxHeight = s.options.MaxImageHeight
 	}
 
-	imagesToLoad := section.ImagePaths
-	if section.Type == SectionTypeImage && len(imagesToLoad) > 0 {
-		imagesToLoad = imagesToLoad[:1]
+	imagePaths := section.ImagePaths
+	if section.Type == SectionTypeImage && len(imagePaths) > 0 {
+		imagePaths = imagePaths[:1]
 	}
 
-	var textures []*sdl.Texture
-	var dimensions []sdl.Rect
-
-	for _, imagePath := range imagesToLoad {
-		texture, rect := s.loadAndScaleImage(imagePath, maxWidth, maxHeight, section)
-		if texture != nil {
-			textures = append(textures, texture)
-			dimensions = append(dimensions, rect)
+	slides := make([]slideshowSlide, len(imagePaths))
+	for i, imagePath := range imagePaths {
+		slides[i].path = imagePath
+		slides[i].rect = s.reservedSlideRect(imagePath, maxWidth, maxHeight, section)
+		if i < len(section.ImageCaptions) {
+			slides[i].caption = section.ImageCaptions[i]
 		}
 	}
 
-	return slideshowState{
-		currentIndex: 0,
-		textures:     textures,
-		dimensions:   dimensions,
+	return &slideshowState{
+		currentIndex:  0,
+		slides:        slides,
+		maxWidth:      maxWidth,
+		maxHeight:     maxHeight,
+		lastAdvance:   time.Now(),
+		previousIndex: -1,
+	}
+}
+
+// createQRCodeState renders a QR code section as a single image so it is laid out,
+// scrolled and cleaned up like an image section.
+func (s *detailScreenState) createQRCodeState(section Section) *slideshowState {
+	if section.QRCodeData == "" {
+		return nil
+	}
+
+	maxSize := internal.Min32(s.options.MaxImageWidth, s.options.MaxImageHeight)
+	if section.MaxWidth > 0 {
+		maxSize = internal.Min32(maxSize, section.MaxWidth)
+	}
+	if section.MaxHeight > 0 {
+		maxSize = internal.Min32(maxSize, section.MaxHeight)
+	}
+
+	texture, rect, err := createQRCodeTexture(s.renderer, section.QRCodeData, section.QRCodeLevel, maxSize)
+	if err != nil {
+		internal.GetInternalLogger().Error("Failed to create QR code", "section", section.Title, "error", err)
+		return nil
+	}
+	rect.X = s.calculateImageX(rect.W, section)
+
+	return &slideshowState{
+		slides:        []slideshowSlide{{texture: texture, rect: rect, loaded: true}},
+		previousIndex: -1,
 	}
 }
 
@@ -476,23 +585,46 @@ func (s *detailScreenState) handleInputEvent(inputEvent *internal.Event) {
 		return
 	}
 
+	if s.handleCustomSectionInput(inputEvent.Button) {
+		return
+	}
+
+	if inputEvent.Button != constants.VirtualButtonUnassigned && inputEvent.Button == s.options.ImageViewerButton && s.openImageViewer() {
+		return
+	}
+
+	// Select sorts a focused table by the column under the cursor, unless it is bound to an action
+	if inputEvent.Button == constants.VirtualButtonSelect && s.options.ActionButton != constants.VirtualButtonSelect &&
+		s.options.ConfirmButton != constants.VirtualButtonSelect && s.toggleTableSort() {
+		return
+	}
+
 	switch inputEvent.Button {
 	case constants.VirtualButtonUp:
-		s.startScrolling(true)
+		if !s.moveFocus(-1) {
+			s.startScrolling(true)
+		}
 	case constants.VirtualButtonDown:
-		s.startScrolling(false)
+		if !s.moveFocus(1) {
+			s.startScrolling(false)
+		}
 	case constants.VirtualButtonLeft, constants.VirtualButtonRight:
 		isLeft := inputEvent.Button == constants.VirtualButtonLeft
-		if s.activeSlideshow >= 0 {
+		switch {
+		case s.moveFocusInRow(isLeft), s.moveTableColumn(isLeft):
+		case s.activeSlideshow >= 0:
 			s.handleSlideshowNavigation(isLeft)
-		} else {
+		default:
 			s.jumpToSection(isLeft)
 		}
 	case constants.VirtualButtonB:
 		s.result.Action = DetailActionCancelled
 	case constants.VirtualButtonA:
-		// A button is used to expand/interact with dropdowns
-		// If no dropdown was activated and A is the ConfirmButton, trigger confirm
+		// A button presses the focused button or expands a dropdown
+		// If nothing was activated and A is the ConfirmButton, trigger confirm
+		if s.activateFocus() {
+			break
+		}
 		if !s.handleDropdownActivation() && s.options.ConfirmButton == constants.VirtualButtonA {
 			s.result.Action = DetailActionConfirmed
 		}
@@ -576,6 +708,10 @@ func (s *detailScreenState) handleDropdownActivation() bool {
 			if state, ok := s.dropdownStates[section.DropdownID]; ok {
 				// Focus and expand this dropdown
 				s.focusedDropdownID = section.DropdownID
+				s.focusedButtonSection = -1
+				s.focusedTableID = ""
+				s.focusedHeader = -1
+				s.focusedCustom = -1
 				state.expanded = true
 				state.highlightedIndex = state.selectedIndex
 				return true
@@ -607,18 +743,54 @@ func (s *detailScreenState) startScrolling(up bool) {
 
 func (s *detailScreenState) handleSlideshowNavigation(isLeft bool) {
 	activeSlideshow := s.findActiveSlideshow()
-	if activeSlideshow >= 0 {
-		if state, ok := s.slideshowStates[activeSlideshow]; ok && len(state.textures) > 1 {
-			if isLeft {
-				state.currentIndex = (state.currentIndex - 1 + len(state.textures)) % len(state.textures)
-			} else {
-				state.currentIndex = (state.currentIndex + 1) % len(state.textures)
-			}
-			s.slideshowStates[activeSlideshow] = state
+	if state, ok := s.slideshowStates[activeSlideshow]; ok {
+		if isLeft {
+			s.advanceSlideshow(activeSlideshow, state, -1)
+		} else {
+			s.advanceSlideshow(activeSlideshow, state, 1)
 		}
 	}
 }
 
+// openImageViewer shows the slideshow or image on screen full screen. Closing the
+// viewer returns to the slideshow at the image last viewed.
+func (s *detailScreenState) openImageViewer() bool {
+	state, ok := s.slideshowStates[s.visibleImageSection]
+	if s.visibleImageSection < 0 || !ok {
+		return false
+	}
+
+	// Hand over the images that haven't failed to load
+	var imagePaths []string
+	var slideIndices []int
+	startIndex := 0
+	for i, slide := range state.slides {
+		if slide.path == "" || !slide.available() {
+			continue
+		}
+		if i == state.currentIndex {
+			startIndex = len(imagePaths)
+		}
+		imagePaths = append(imagePaths, slide.path)
+		slideIndices = append(slideIndices, i)
+	}
+	if len(imagePaths) == 0 {
+		return false
+	}
+
+	result, err := ImageViewer(imagePaths, ImageViewerOptions{StartIndex: startIndex})
+	if err == nil && s.options.Sections[s.visibleImageSection].Type == SectionTypeSlideshow {
+		state.currentIndex = slideIndices[result.Index]
+		state.previousIndex = -1
+		state.lastAdvance = time.Now()
+	}
+
+	// Buttons released inside the viewer never reached this screen
+	s.directionalInput.Reset()
+	s.lastInputTime = time.Now()
+	return true
+}
+
 func (s *detailScreenState) jumpToSection(backward bool) {
 	if len(s.sectionOffsets) == 0 {
 		return
@@ -656,6 +828,8 @@ func (s *detailScreenState) findActiveSlideshow() int {
 
 func (s *detailScreenState) update() {
 	s.handleDirectionalRepeats()
+	s.updateSlideshows()
+	s.updateSectionExpansions()
 
 	diff := s.targetScrollY - s.scrollY
 	if diff == 0 {
@@ -690,6 +864,7 @@ func (s *detailScreenState) render() {
 	margins := internal.UniformPadding(20)
 	footerHeight := int32(30)
 	safeAreaHeight := s.window.GetHeight() - footerHeight
+	s.viewportHeight = safeAreaHeight
 
 	statusBarWidth := calculateStatusBarWidth(internal.Fonts.SmallFont, s.options.StatusBar)
 
@@ -765,7 +940,9 @@ func (s *detailScreenState) renderSections(margins internal.Padding, startY int3
 	}
 
 	s.activeSlideshow = -1
+	s.visibleImageSection = -1
 	s.visibleDropdownID = ""
+	s.focusables = s.focusables[:0]
 	s.sectionOffsets = make([]int32, len(s.options.Sections))
 
 	for sectionIndex, section := range s.options.Sections {
@@ -776,6 +953,12 @@ func (s *detailScreenState) renderSections(margins internal.Padding, startY int3
 		// Record the absolute Y position of this section (add back scrollY since currentY has it subtracted)
 		s.sectionOffsets[sectionIndex] = currentY + s.scrollY
 
+		if expansion, ok := s.sectionExpansions[sectionIndex]; ok {
+			currentY = s.renderSectionHeader(sectionIndex, expansion, margins, contentWidth, currentY, safeAreaHeight)
+			currentY = s.renderCollapsibleContent(sectionIndex, section, expansion, margins, contentWidth, currentY, safeAreaHeight)
+			continue
+		}
+
 		currentY = s.renderSectionTitle(sectionIndex, margins, currentY, safeAreaHeight)
 		currentY = s.renderSectionDivider(margins, contentWidth, currentY, safeAreaHeight)
 		currentY = s.renderSectionContent(sectionIndex, section, margins, contentWidth, currentY, safeAreaHeight)
@@ -820,9 +1003,9 @@ func (s *detailScreenState) renderSectionDivider(margins internal.Padding, conte
 func (s *detailScreenState) renderSectionContent(sectionIndex int, section Section, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
 	switch section.Type {
 	case SectionTypeSlideshow:
-		return s.renderSlideshow(sectionIndex, currentY, safeAreaHeight)
+		return s.renderSlideshow(sectionIndex, margins, contentWidth, currentY, safeAreaHeight)
 	case SectionTypeImage:
-		return s.renderImage(sectionIndex, currentY, safeAreaHeight)
+		return s.renderImage(sectionIndex, margins, contentWidth, currentY, safeAreaHeight)
 	case SectionTypeInfo:
 		return s.renderInfo(sectionIndex, section, margins, contentWidth, currentY, safeAreaHeight)
 	case SectionTypeDescription:
@@ -831,43 +1014,69 @@ func (s *detailScreenState) renderSectionContent(sectionIndex int, section Secti
 		return s.renderDropdown(section, margins, contentWidth, currentY, safeAreaHeight)
 	case SectionTypeTable:
 		return s.renderTable(section, margins, contentWidth, currentY, safeAreaHeight)
+	case SectionTypeMarkdown:
+		return s.renderMarkdown(sectionIndex, section, margins, contentWidth, currentY, safeAreaHeight)
+	case SectionTypeQRCode:
+		return s.renderQRCode(sectionIndex, section, margins, contentWidth, currentY, safeAreaHeight)
+	case SectionTypeButtons:
+		return s.renderButtons(sectionIndex, section, margins, contentWidth, currentY, safeAreaHeight)
+	case SectionTypeCustom:
+		return s.renderCustom(sectionIndex, section, margins, contentWidth, currentY, safeAreaHeight)
+	case SectionTypeChart:
+		return s.renderChart(section, margins, contentWidth, currentY, safeAreaHeight)
 	}
 	return currentY
 }
 
-func (s *detailScreenState) renderSlideshow(sectionIndex int, currentY int32, safeAreaHeight int32) int32 {
+func (s *detailScreenState) renderSlideshow(sectionIndex int, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
 	state, ok := s.slideshowStates[sectionIndex]
-	if !ok || len(state.textures) == 0 {
+	if !ok || len(state.slides) == 0 {
+		return currentY
+	}
+
+	s.prepareSlides(sectionIndex, state, currentY, safeAreaHeight)
+	slide := state.slides[state.currentIndex]
+	if !slide.available() {
+		state.visible = false
 		return currentY
 	}
 
-	imageRect := state.dimensions[state.currentIndex]
+	imageRect := slide.rect
 	imageRect.Y = currentY
 
-	if isRectVisible(imageRect, safeAreaHeight) {
-		s.renderer.Copy(state.textures[state.currentIndex], nil, &imageRect)
+	// An unloaded slide keeps its reserved space but isn't drawn or made active
+	state.visible = slide.texture != nil && isRectVisible(imageRect, safeAreaHeight)
+	if state.visible {
+		s.renderSlide(sectionIndex, state, currentY)
 		// Set this as the active slideshow when it's being rendered and visible
 		s.activeSlideshow = sectionIndex
+		s.visibleImageSection = sectionIndex
 	}
 
 	currentY += imageRect.H + 15
+	currentY = s.renderSlideCaption(slide, margins, contentWidth, currentY, safeAreaHeight)
 
-	if len(state.textures) > 1 {
+	if state.availableCount() > 1 {
 		currentY = s.renderSlideshowIndicators(state, currentY)
 	}
 
 	return currentY
 }
 
-func (s *detailScreenState) renderSlideshowIndicators(state slideshowState, currentY int32) int32 {
+func (s *detailScreenState) renderSlideshowIndicators(state *slideshowState, currentY int32) int32 {
 	indicatorSize := int32(10)
 	indicatorSpacing := int32(5)
-	totalIndicatorsWidth := (indicatorSize * int32(len(state.textures))) + (indicatorSpacing * int32(len(state.textures)-1))
+	count := int32(state.availableCount())
+	totalIndicatorsWidth := (indicatorSize * count) + (indicatorSpacing * (count - 1))
 
 	indicatorX := (s.window.GetWidth() - totalIndicatorsWidth) / 2
 	indicatorY := currentY
 
-	for i := 0; i < len(state.textures); i++ {
+	for i, slide := range state.slides {
+		if !slide.available() {
+			continue
+		}
+
 		if i == state.currentIndex {
 			s.renderer.SetDrawColor(255, 255, 255, 255)
 		} else {
@@ -888,20 +1097,52 @@ func (s *detailScreenState) renderSlideshowIndicators(state slideshowState, curr
 	return currentY + indicatorSize + 15
 }
 
-func (s *detailScreenState) renderImage(sectionIndex int, currentY int32, safeAreaHeight int32) int32 {
+func (s *detailScreenState) renderImage(sectionIndex int, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
 	state, ok := s.slideshowStates[sectionIndex]
-	if !ok || len(state.textures) == 0 {
+	if !ok || len(state.slides) == 0 {
 		return currentY
 	}
 
-	imageRect := state.dimensions[0]
+	s.prepareSlides(sectionIndex, state, currentY, safeAreaHeight)
+	slide := state.slides[0]
+	if !slide.available() {
+		return currentY
+	}
+
+	imageRect := slide.rect
 	imageRect.Y = currentY
 
-	if isRectVisible(imageRect, safeAreaHeight) {
-		s.renderer.Copy(state.textures[0], nil, &imageRect)
+	if slide.texture != nil && isRectVisible(imageRect, safeAreaHeight) {
+		s.renderer.Copy(slide.texture, nil, &imageRect)
+		if slide.path != "" {
+			s.visibleImageSection = sectionIndex
+		}
+	}
+
+	return s.renderSlideCaption(slide, margins, contentWidth, currentY+imageRect.H+15, safeAreaHeight)
+}
+
+func (s *detailScreenState) renderQRCode(sectionIndex int, section Section, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
+	currentY = s.renderImage(sectionIndex, margins, contentWidth, currentY, safeAreaHeight)
+	if section.Caption == "" {
+		return currentY
+	}
+
+	captionHeight := calculateMultilineTextHeight(section.Caption, internal.Fonts.SmallFont, contentWidth)
+	if isRectVisible(sdl.Rect{X: margins.Left, Y: currentY, W: contentWidth, H: captionHeight}, safeAreaHeight) {
+		internal.RenderMultilineTextWithCache(
+			s.renderer,
+			section.Caption,
+			internal.Fonts.SmallFont,
+			contentWidth,
+			margins.Left,
+			currentY,
+			s.options.DescriptionColor,
+			constants.TextAlignCenter,
+			s.textureCache)
 	}
 
-	return currentY + imageRect.H + 15
+	return currentY + captionHeight
 }
 
 func (s *detailScreenState) renderInfo(sectionIndex int, section Section, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
@@ -1000,6 +1241,11 @@ func (s *detailScreenState) renderDropdown(section Section, margins internal.Pad
 	itemHeight := int32(35)
 	isFocused := s.focusedDropdownID == section.DropdownID
 
+	s.focusables = append(s.focusables, detailFocusable{
+		dropdownID: section.DropdownID,
+		rect:       sdl.Rect{X: dropdownX, Y: currentY + s.scrollY, W: dropdownWidth, H: itemHeight},
+	})
+
 	if state.expanded {
 		return s.renderExpandedDropdown(section, state, dropdownX, dropdownWidth, itemHeight, currentY, safeAreaHeight)
 	}
@@ -1140,201 +1386,30 @@ func (s *detailScreenState) renderDropdownArrow(x, y int32, expanded bool) {
 	}
 }
 
-func (s *detailScreenState) renderTable(section Section, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
-	if len(section.TableRows) == 0 {
-		return currentY
-	}
-
-	font := internal.Fonts.SmallFont
-	headerFont := internal.Fonts.SmallFont
-
-	_, fontHeight, err := font.SizeUTF8("Aj")
-	if err != nil {
-		fontHeight = 20
-	}
-	headerFontHeight := fontHeight
-
-	cellPaddingX := int32(8)
-	cellPaddingY := int32(6)
-
-	// Determine column count from headers or first row
-	numCols := len(section.TableHeaders)
-	for _, row := range section.TableRows {
-		if len(row.Cells) > numCols {
-			numCols = len(row.Cells)
-		}
-	}
-	if numCols == 0 {
-		return currentY
-	}
-
-	// Calculate column widths by measuring max text width per column
-	colWidths := make([]int32, numCols)
-	for col := 0; col < numCols; col++ {
-		// Measure header
-		if col < len(section.TableHeaders) && section.TableHeaders[col] != "" {
-			w, _, err := headerFont.SizeUTF8(section.TableHeaders[col])
-			if err == nil && int32(w) > colWidths[col] {
-				colWidths[col] = int32(w)
-			}
-		}
-		// Measure all rows
-		for _, row := range section.TableRows {
-			if col < len(row.Cells) && row.Cells[col] != "" {
-				w, _, err := font.SizeUTF8(row.Cells[col])
-				if err == nil && int32(w) > colWidths[col] {
-					colWidths[col] = int32(w)
-				}
-			}
-		}
-		colWidths[col] += cellPaddingX * 2
-	}
+func (s *detailScreenState) updateScrollLimits(totalContentHeight int32, safeAreaHeight int32, margins internal.Padding) {
+	s.maxScrollY = internal.Max32(0, totalContentHeight-safeAreaHeight+margins.Bottom)
 
-	// Scale columns to fit contentWidth
-	totalNatural := int32(0)
-	for _, w := range colWidths {
-		totalNatural += w
-	}
-	if totalNatural > 0 {
-		if totalNatural > contentWidth {
-			// Scale down proportionally
-			for i := range colWidths {
-				colWidths[i] = int32(float64(colWidths[i]) * float64(contentWidth) / float64(totalNatural))
-				if colWidths[i] < cellPaddingX*2+10 {
-					colWidths[i] = cellPaddingX*2 + 10
-				}
-			}
-		} else if totalNatural < contentWidth {
-			// Distribute remaining space proportionally
-			remaining := contentWidth - totalNatural
-			for i := range colWidths {
-				extra := int32(float64(remaining) * float64(colWidths[i]) / float64(totalNatural))
-				colWidths[i] += extra
-			}
-		}
+	// Content can shrink when a section collapses; ease back within the new limit
+	if s.targetScrollY > s.maxScrollY {
+		s.targetScrollY = s.maxScrollY
 	}
+}
 
-	tableX := margins.Left
-	hasHeaders := len(section.TableHeaders) > 0
-
-	// Render header row
-	if hasHeaders {
-		headerHeight := int32(headerFontHeight) + cellPaddingY*2
-		headerRect := sdl.Rect{X: tableX, Y: currentY, W: contentWidth, H: headerHeight}
-
-		if isRectVisible(headerRect, safeAreaHeight) {
-			// Draw header background
-			s.renderer.SetDrawColor(50, 50, 50, 255)
-			s.renderer.FillRect(&headerRect)
-
-			cellX := tableX
-			for col := 0; col < numCols; col++ {
-				if col < len(section.TableHeaders) && section.TableHeaders[col] != "" {
-					textX := cellX + cellPaddingX
-					textY := currentY + cellPaddingY
-					maxCellWidth := colWidths[col] - cellPaddingX*2
-
-					internal.RenderMultilineTextWithCache(
-						s.renderer,
-						section.TableHeaders[col],
-						headerFont,
-						maxCellWidth,
-						textX,
-						textY,
-						s.options.TitleColor,
-						constants.TextAlignLeft,
-						s.textureCache)
-				}
-
-				// Draw vertical lines for Full grid
-				if section.TableGrid == TableGridFull && col > 0 {
-					s.renderer.SetDrawColor(80, 80, 80, 255)
-					s.renderer.DrawLine(cellX, currentY, cellX, currentY+headerHeight)
-				}
-
-				cellX += colWidths[col]
-			}
-
-			// Draw bottom border for header
-			if section.TableGrid != TableGridNone {
-				s.renderer.SetDrawColor(80, 80, 80, 255)
-				s.renderer.DrawLine(tableX, currentY+headerHeight, tableX+contentWidth, currentY+headerHeight)
-			}
-		}
-
-		currentY += int32(headerFontHeight) + cellPaddingY*2
+// setClip narrows drawing to rect, within any clip already set, and returns a func
+// that restores the previous clip.
+func (s *detailScreenState) setClip(rect sdl.Rect) func() {
+	if !s.renderer.IsClipEnabled() {
+		s.renderer.SetClipRect(&rect)
+		return func() { s.renderer.SetClipRect(nil) }
 	}
 
-	// Render data rows
-	for rowIdx, row := range section.TableRows {
-		// Calculate row height based on tallest cell
-		rowHeight := int32(fontHeight) + cellPaddingY*2
-		for col := 0; col < numCols && col < len(row.Cells); col++ {
-			if row.Cells[col] != "" {
-				maxCellWidth := colWidths[col] - cellPaddingX*2
-				if maxCellWidth < 10 {
-					maxCellWidth = 10
-				}
-				cellHeight := calculateMultilineTextHeight(row.Cells[col], font, maxCellWidth) - 20 // subtract trailing padding baked into calculateMultilineTextHeight
-				cellHeightWithPadding := cellHeight + cellPaddingY*2
-				if cellHeightWithPadding > rowHeight {
-					rowHeight = cellHeightWithPadding
-				}
-			}
-		}
-
-		rowRect := sdl.Rect{X: tableX, Y: currentY, W: contentWidth, H: rowHeight}
-
-		if isRectVisible(rowRect, safeAreaHeight) {
-			// Alternating row backgrounds
-			if section.TableGrid == TableGridAlternatingRows && rowIdx%2 == 1 {
-				s.renderer.SetDrawColor(35, 35, 35, 255)
-				s.renderer.FillRect(&rowRect)
-			}
-
-			cellX := tableX
-			for col := 0; col < numCols; col++ {
-				if col < len(row.Cells) && row.Cells[col] != "" {
-					textX := cellX + cellPaddingX
-					textY := currentY + cellPaddingY
-					maxCellWidth := colWidths[col] - cellPaddingX*2
-
-					internal.RenderMultilineTextWithCache(
-						s.renderer,
-						row.Cells[col],
-						font,
-						maxCellWidth,
-						textX,
-						textY,
-						s.options.DescriptionColor,
-						constants.TextAlignLeft,
-						s.textureCache)
-				}
-
-				// Draw vertical lines for Full grid
-				if section.TableGrid == TableGridFull && col > 0 {
-					s.renderer.SetDrawColor(80, 80, 80, 255)
-					s.renderer.DrawLine(cellX, currentY, cellX, currentY+rowHeight)
-				}
-
-				cellX += colWidths[col]
-			}
-
-			// Draw row divider
-			if section.TableGrid == TableGridRowDividers || section.TableGrid == TableGridFull {
-				s.renderer.SetDrawColor(80, 80, 80, 255)
-				s.renderer.DrawLine(tableX, currentY+rowHeight, tableX+contentWidth, currentY+rowHeight)
-			}
-		}
-
-		currentY += rowHeight
+	previous := s.renderer.GetClipRect()
+	clip, ok := rect.Intersect(&previous)
+	if !ok {
+		clip = sdl.Rect{X: rect.X, Y: rect.Y}
 	}
-
-	return currentY + 15
-}
-
-func (s *detailScreenState) updateScrollLimits(totalContentHeight int32, safeAreaHeight int32, margins internal.Padding) {
-	s.maxScrollY = internal.Max32(0, totalContentHeight-safeAreaHeight+margins.Bottom)
+	s.renderer.SetClipRect(&clip)
+	return func() { s.renderer.SetClipRect(&previous) }
 }
 
 func (s *detailScreenState) renderScrollbar(safeAreaHeight int32) {
@@ -1421,10 +1496,16 @@ func (s *detailScreenState) cleanup() {
 	}
 
 	for _, state := range s.slideshowStates {
-		for _, texture := range state.textures {
-			texture.Destroy()
+		for _, slide := range state.slides {
+			if slide.texture != nil {
+				slide.texture.Destroy()
+			}
 		}
 	}
+
+	for _, layout := range s.markdownLayouts {
+		layout.destroy()
+	}
 }
 
 func renderText(renderer *sdl.Renderer, text string, font *ttf.Font, color sdl.Color) *sdl.Texture {
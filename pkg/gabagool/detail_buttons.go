@@ -0,0 +1,291 @@
+package gabagool
+
+import (
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// DetailButton is a focusable button in a buttons section.
+type DetailButton struct {
+	ID    string // Returned in DetailScreenResult.ButtonID when activated
+	Label string // Display text
+}
+
+// detailFocusable is a control that can take focus: a dropdown, a button, a table row,
+// the header of a collapsible section or a custom section that handles input.
+// Its rect is in content coordinates, independent of the scroll position.
+type detailFocusable struct {
+	dropdownID   string
+	tableID      string
+	row          int
+	button       bool
+	buttonIndex  int // index into the section's Buttons
+	header       bool
+	custom       bool
+	sectionIndex int
+	rect         sdl.Rect
+}
+
+const (
+	detailButtonHeight   = int32(35)
+	detailButtonSpacing  = int32(10)
+	detailButtonPaddingX = int32(20)
+	detailButtonMinWidth = int32(100)
+)
+
+// NewButtonsSection creates a row of focusable buttons. Activating one closes the
+// DetailScreen with DetailActionButtonPressed and the button's ID in the result.
+func NewButtonsSection(title string, buttons []DetailButton) Section {
+	return Section{
+		Type:    SectionTypeButtons,
+		Title:   title,
+		Buttons: buttons,
+	}
+}
+
+func (s *detailScreenState) renderButtons(sectionIndex int, section Section, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
+	if len(section.Buttons) == 0 {
+		return currentY
+	}
+
+	font := internal.Fonts.SmallFont
+	x := margins.Left
+
+	for i, button := range section.Buttons {
+		width := detailButtonMinWidth
+		if w, _, err := font.SizeUTF8(button.Label); err == nil {
+			width = internal.Max32(width, int32(w)+detailButtonPaddingX*2)
+		}
+		width = internal.Min32(width, contentWidth)
+
+		// Wrap onto a new row when the button doesn't fit
+		if x > margins.Left && x+width > margins.Left+contentWidth {
+			x = margins.Left
+			currentY += detailButtonHeight + detailButtonSpacing
+		}
+
+		rect := sdl.Rect{X: x, Y: currentY, W: width, H: detailButtonHeight}
+		s.focusables = append(s.focusables, detailFocusable{
+			button:       true,
+			buttonIndex:  i,
+			sectionIndex: sectionIndex,
+			rect:         sdl.Rect{X: rect.X, Y: rect.Y + s.scrollY, W: rect.W, H: rect.H},
+		})
+
+		if isRectVisible(rect, safeAreaHeight) {
+			s.renderButton(button, rect, s.focusedButtonSection == sectionIndex && s.focusedButton == i)
+		}
+
+		x += width + detailButtonSpacing
+	}
+
+	return currentY + detailButtonHeight + 15
+}
+
+func (s *detailScreenState) renderButton(button DetailButton, rect sdl.Rect, isFocused bool) {
+	bgColor := sdl.Color{R: 40, G: 40, B: 40, A: 255}
+	borderColor := sdl.Color{R: 80, G: 80, B: 80, A: 255}
+	textColor := sdl.Color{R: 200, G: 200, B: 200, A: 255}
+	if isFocused {
+		bgColor = sdl.Color{R: 60, G: 60, B: 80, A: 255}
+		borderColor = sdl.Color{R: 100, G: 100, B: 200, A: 255}
+		textColor = sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	}
+
+	s.renderer.SetDrawColor(bgColor.R, bgColor.G, bgColor.B, bgColor.A)
+	s.renderer.FillRect(&rect)
+	s.renderer.SetDrawColor(borderColor.R, borderColor.G, borderColor.B, borderColor.A)
+	s.renderer.DrawRect(&rect)
+
+	textY := rect.Y + (rect.H-int32(internal.Fonts.SmallFont.Height()))/2
+	internal.RenderMultilineTextWithCache(
+		s.renderer,
+		button.Label,
+		internal.Fonts.SmallFont,
+		rect.W,
+		rect.X,
+		textY,
+		textColor,
+		constants.TextAlignCenter,
+		s.textureCache)
+}
+
+// focusedIndex returns the index of the focused control in focusables, or -1.
+func (s *detailScreenState) focusedIndex() int {
+	for i, f := range s.focusables {
+		if (f.button && f.sectionIndex == s.focusedButtonSection && f.buttonIndex == s.focusedButton) ||
+			(f.dropdownID != "" && f.dropdownID == s.focusedDropdownID) ||
+			(f.tableID != "" && f.tableID == s.focusedTableID && f.row == s.focusedTableRow) ||
+			(f.header && f.sectionIndex == s.focusedHeader) ||
+			(f.custom && f.sectionIndex == s.focusedCustom) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *detailScreenState) setFocus(index int) {
+	s.focusedDropdownID = s.focusables[index].dropdownID
+	s.focusedButtonSection = -1
+	if s.focusables[index].button {
+		s.focusedButtonSection = s.focusables[index].sectionIndex
+		s.focusedButton = s.focusables[index].buttonIndex
+	}
+	s.focusedTableID = s.focusables[index].tableID
+	s.focusedTableRow = s.focusables[index].row
+	s.focusedHeader = -1
+	if s.focusables[index].header {
+		s.focusedHeader = s.focusables[index].sectionIndex
+	}
+	s.focusedCustom = -1
+	if s.focusables[index].custom {
+		s.focusedCustom = s.focusables[index].sectionIndex
+	}
+}
+
+func (s *detailScreenState) clearFocus() {
+	s.focusedDropdownID = ""
+	s.focusedButtonSection = -1
+	s.focusedTableID = ""
+	s.focusedHeader = -1
+	s.focusedCustom = -1
+}
+
+// isOnScreen reports whether any part of a content rect is inside the viewport
+// the page is scrolling to.
+func (s *detailScreenState) isOnScreen(rect sdl.Rect) bool {
+	return rect.Y+rect.H > s.targetScrollY && rect.Y < s.targetScrollY+s.viewportHeight
+}
+
+// moveFocus moves focus to the nearest control in the row above (direction < 0) or
+// below. Without focus it picks up the first control on screen in that direction.
+// It returns false when there is no control close enough, so the page scrolls instead.
+func (s *detailScreenState) moveFocus(direction int) bool {
+	current := s.focusedIndex()
+	if current >= 0 && !s.isOnScreen(s.focusables[current].rect) {
+		// Focus was scrolled away
+		s.clearFocus()
+		current = -1
+	}
+
+	next := -1
+	if current < 0 {
+		for i := range s.focusables {
+			if direction < 0 {
+				i = len(s.focusables) - 1 - i
+			}
+			rect := s.focusables[i].rect
+			if rect.Y >= s.targetScrollY && rect.Y+rect.H <= s.targetScrollY+s.viewportHeight {
+				next = i
+				break
+			}
+		}
+	} else {
+		from := s.focusables[current].rect
+		bestDY, bestDX := int32(-1), int32(0)
+		for i, f := range s.focusables {
+			dy := f.rect.Y - from.Y
+			if direction < 0 {
+				dy = -dy
+			}
+			if dy <= 0 {
+				continue
+			}
+			dx := internal.Abs32((f.rect.X + f.rect.W/2) - (from.X + from.W/2))
+			if bestDY < 0 || dy < bestDY || (dy == bestDY && dx < bestDX) {
+				next, bestDY, bestDX = i, dy, dx
+			}
+		}
+
+		// Only step to controls within one scroll of the screen edge
+		if next >= 0 {
+			rect := s.focusables[next].rect
+			if direction > 0 && rect.Y+rect.H > s.targetScrollY+s.viewportHeight+detailScrollSpeed ||
+				direction < 0 && rect.Y < s.targetScrollY-detailScrollSpeed {
+				next = -1
+			}
+		}
+	}
+
+	if next < 0 {
+		return false
+	}
+	s.setFocus(next)
+	s.scrollIntoView(s.focusables[next].rect)
+	return true
+}
+
+// moveFocusInRow moves focus to the neighbouring control on the same row. It returns
+// false unless a button on screen has focus, leaving left/right to slideshows and
+// section jumps.
+func (s *detailScreenState) moveFocusInRow(left bool) bool {
+	current := s.focusedIndex()
+	if current < 0 || !s.focusables[current].button || !s.isOnScreen(s.focusables[current].rect) {
+		return false
+	}
+
+	from := s.focusables[current].rect
+	next, bestDX := -1, int32(0)
+	for i, f := range s.focusables {
+		if f.rect.Y != from.Y {
+			continue
+		}
+		dx := f.rect.X - from.X
+		if left {
+			dx = -dx
+		}
+		if dx > 0 && (next < 0 || dx < bestDX) {
+			next, bestDX = i, dx
+		}
+	}
+
+	if next >= 0 {
+		s.setFocus(next)
+	}
+	return true
+}
+
+func (s *detailScreenState) scrollIntoView(rect sdl.Rect) {
+	margin := int32(20)
+	if rect.Y-margin < s.targetScrollY {
+		s.targetScrollY = internal.Max32(0, rect.Y-margin)
+	} else if rect.Y+rect.H+margin > s.targetScrollY+s.viewportHeight {
+		s.targetScrollY = internal.Min32(s.maxScrollY, rect.Y+rect.H+margin-s.viewportHeight)
+	}
+}
+
+// activateFocus presses the focused button, opens the focused dropdown, selects the
+// focused table row or expands or collapses the focused section.
+// It returns false when nothing on screen has focus.
+func (s *detailScreenState) activateFocus() bool {
+	current := s.focusedIndex()
+	if current < 0 || !s.isOnScreen(s.focusables[current].rect) {
+		return false
+	}
+
+	focused := s.focusables[current]
+	if focused.button {
+		s.result.Action = DetailActionButtonPressed
+		s.result.ButtonID = s.options.Sections[focused.sectionIndex].Buttons[focused.buttonIndex].ID
+		return true
+	}
+
+	if focused.tableID != "" {
+		s.toggleTableRowSelection(focused.tableID, focused.row)
+		return true
+	}
+
+	if focused.header {
+		s.toggleSectionExpansion(focused.sectionIndex)
+		return true
+	}
+
+	state, ok := s.dropdownStates[focused.dropdownID]
+	if !ok {
+		return false
+	}
+	state.expanded = true
+	state.highlightedIndex = state.selectedIndex
+	return true
+}
@@ -0,0 +1,404 @@
+package gabagool
+
+import (
+	"math"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ChartType selects how a chart section draws its series.
+type ChartType int
+
+const (
+	ChartTypeBar       ChartType = iota // Bars grouped by category, with axes
+	ChartTypeLine                       // Lines through the values of each category, with axes
+	ChartTypeSparkline                  // Small line without axes, for a trend at a glance
+)
+
+// ChartSeries is one set of values in a chart section, one per category. NaN values
+// are left out, which breaks line charts at that category.
+type ChartSeries struct {
+	Name   string    // Shown in the legend of charts with several series
+	Values []float64 // Values by category
+	Color  sdl.Color // Series color (default: derived from the theme accent)
+}
+
+const (
+	chartDefaultHeight     = int32(180)
+	sparklineDefaultHeight = int32(40)
+	chartMaxTicks          = 5
+	chartLabelGap          = int32(8)
+	chartSwatchSize        = int32(10)
+)
+
+var (
+	chartAxisColor  = sdl.Color{R: 120, G: 120, B: 120, A: 255}
+	chartGridColor  = sdl.Color{R: 50, G: 50, B: 50, A: 255}
+	chartLabelColor = sdl.Color{R: 150, G: 150, B: 150, A: 255}
+)
+
+// NewChartSection creates a chart section. labels name the categories along the x
+// axis, and each series has a value per category.
+func NewChartSection(title string, chartType ChartType, labels []string, series []ChartSeries) Section {
+	return Section{
+		Type:        SectionTypeChart,
+		Title:       title,
+		ChartType:   chartType,
+		ChartLabels: labels,
+		ChartSeries: series,
+	}
+}
+
+func chartSeriesColor(series ChartSeries, index int) sdl.Color {
+	if series.Color.A != 0 {
+		return series.Color
+	}
+	return internal.ChartSeriesColor(internal.GetTheme().AccentColor, index)
+}
+
+func chartCategoryCount(section Section) int {
+	count := len(section.ChartLabels)
+	for _, series := range section.ChartSeries {
+		count = max(count, len(series.Values))
+	}
+	return count
+}
+
+func isChartValue(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// chartValueRange returns the smallest and largest values of all series, widened to
+// take in zero when includeZero is set.
+func chartValueRange(series []ChartSeries, includeZero bool) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for _, value := range s.Values {
+			if isChartValue(value) {
+				lo, hi = math.Min(lo, value), math.Max(hi, value)
+			}
+		}
+	}
+	if lo > hi {
+		return 0, 0
+	}
+	if includeZero {
+		lo, hi = math.Min(lo, 0), math.Max(hi, 0)
+	}
+	return lo, hi
+}
+
+func chartTextWidth(text string) int32 {
+	w, _, err := internal.Fonts.TinyFont.SizeUTF8(text)
+	if err != nil {
+		return 0
+	}
+	return int32(w)
+}
+
+// renderChartText draws a line of text anchored at x by its left edge, center or
+// right edge, depending on align.
+func (s *detailScreenState) renderChartText(text string, x, y int32, align constants.TextAlign, color sdl.Color) {
+	w := chartTextWidth(text)
+	switch align {
+	case constants.TextAlignCenter:
+		x -= w / 2
+	case constants.TextAlignRight:
+		x -= w
+	}
+	internal.RenderMultilineTextWithCache(s.renderer, text, internal.Fonts.TinyFont, w+1, x, y, color, constants.TextAlignLeft, s.textureCache)
+}
+
+func (s *detailScreenState) renderChart(section Section, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
+	count := chartCategoryCount(section)
+	if count == 0 {
+		return currentY
+	}
+
+	height := section.ChartHeight
+	if height <= 0 {
+		height = chartDefaultHeight
+		if section.ChartType == ChartTypeSparkline {
+			height = sparklineDefaultHeight
+		}
+	}
+
+	if section.ChartType != ChartTypeSparkline && len(section.ChartSeries) > 1 {
+		currentY = s.renderChartLegend(section, margins.Left, contentWidth, currentY, safeAreaHeight)
+	}
+
+	rect := sdl.Rect{X: margins.Left, Y: currentY, W: contentWidth, H: height}
+	if isRectVisible(rect, safeAreaHeight) {
+		if section.ChartType == ChartTypeSparkline {
+			s.renderSparkline(section, rect, count)
+		} else {
+			s.renderAxisChart(section, rect, count)
+		}
+	}
+
+	return currentY + height + 15
+}
+
+// renderChartLegend draws a swatch and name for each series, wrapping onto more rows
+// as needed, and returns the y below it.
+func (s *detailScreenState) renderChartLegend(section Section, x, width, currentY int32, safeAreaHeight int32) int32 {
+	rowHeight := int32(internal.Fonts.TinyFont.Height())
+	itemX := x
+
+	for i, series := range section.ChartSeries {
+		itemWidth := chartSwatchSize + 6 + chartTextWidth(series.Name)
+		if itemX > x && itemX+itemWidth > x+width {
+			itemX = x
+			currentY += rowHeight + 4
+		}
+
+		if isLineVisible(currentY+rowHeight/2, safeAreaHeight) {
+			color := chartSeriesColor(series, i)
+			s.renderer.SetDrawColor(color.R, color.G, color.B, color.A)
+			s.renderer.FillRect(&sdl.Rect{X: itemX, Y: currentY + (rowHeight-chartSwatchSize)/2, W: chartSwatchSize, H: chartSwatchSize})
+			s.renderChartText(series.Name, itemX+chartSwatchSize+6, currentY, constants.TextAlignLeft, s.options.DescriptionColor)
+		}
+
+		itemX += itemWidth + 16
+	}
+
+	return currentY + rowHeight + 10
+}
+
+// renderAxisChart draws a bar or line chart with a value axis on the left, grid lines
+// at round values and category labels below. Labels that would overlap are thinned out,
+// so the chart stays readable however narrow the section is.
+func (s *detailScreenState) renderAxisChart(section Section, rect sdl.Rect, count int) {
+	fontHeight := int32(internal.Fonts.TinyFont.Height())
+	isBar := section.ChartType == ChartTypeBar
+
+	lo, hi := chartValueRange(section.ChartSeries, isBar)
+	scaleMin, scaleMax, step := internal.NiceChartScale(lo, hi, chartMaxTicks)
+
+	tickCount := int(math.Round((scaleMax - scaleMin) / step))
+	ticks := make([]float64, 0, tickCount+1)
+	axisWidth := int32(0)
+	for i := 0; i <= tickCount; i++ {
+		tick := scaleMin + float64(i)*step
+		ticks = append(ticks, tick)
+		axisWidth = max(axisWidth, chartTextWidth(internal.FormatChartValue(tick)))
+	}
+	axisWidth += chartLabelGap
+
+	// Leave room for the top tick label, or for value labels above the tallest values
+	top := rect.Y + fontHeight/2
+	if section.ChartShowValues {
+		top = rect.Y + fontHeight + 2
+	}
+	bottom := rect.Y + rect.H
+	if len(section.ChartLabels) > 0 {
+		bottom -= fontHeight + 6
+	}
+
+	plot := sdl.Rect{X: rect.X + axisWidth, Y: top, W: rect.W - axisWidth, H: bottom - top}
+	if plot.W <= 0 || plot.H <= 0 {
+		return
+	}
+
+	valueY := func(value float64) int32 {
+		return plot.Y + plot.H - int32(math.Round((value-scaleMin)/(scaleMax-scaleMin)*float64(plot.H)))
+	}
+
+	for _, tick := range ticks {
+		y := valueY(tick)
+		s.renderer.SetDrawColor(chartGridColor.R, chartGridColor.G, chartGridColor.B, chartGridColor.A)
+		s.renderer.DrawLine(plot.X+1, y, plot.X+plot.W, y)
+		s.renderChartText(internal.FormatChartValue(tick), plot.X-chartLabelGap, y-fontHeight/2, constants.TextAlignRight, chartLabelColor)
+	}
+
+	// Bars grow from zero; lines sit on the bottom of the scale
+	baseline := plot.Y + plot.H
+	if isBar {
+		baseline = valueY(math.Max(scaleMin, math.Min(0, scaleMax)))
+	}
+	s.renderer.SetDrawColor(chartAxisColor.R, chartAxisColor.G, chartAxisColor.B, chartAxisColor.A)
+	s.renderer.DrawLine(plot.X, plot.Y, plot.X, plot.Y+plot.H)
+	s.renderer.DrawLine(plot.X, baseline, plot.X+plot.W, baseline)
+
+	slot := float64(plot.W) / float64(count)
+	if isBar {
+		s.renderChartBars(section, plot, slot, baseline, valueY)
+	} else {
+		s.renderChartLines(section, plot, slot, valueY)
+	}
+
+	s.renderChartCategoryLabels(section, rect, plot, slot)
+}
+
+func (s *detailScreenState) renderChartBars(section Section, plot sdl.Rect, slot float64, baseline int32, valueY func(float64) int32) {
+	fontHeight := int32(internal.Fonts.TinyFont.Height())
+	seriesCount := len(section.ChartSeries)
+	groupWidth := slot * 0.7
+	barWidth := max(int32(groupWidth/float64(seriesCount)), 1)
+
+	// Value labels go over the bar, or the whole group for single-series charts
+	labelWidth := int32(slot)
+	if seriesCount > 1 {
+		labelWidth = barWidth + 4
+	}
+
+	for i := 0; i < chartCategoryCount(section); i++ {
+		groupX := plot.X + int32(slot*float64(i)+(slot-float64(barWidth)*float64(seriesCount))/2)
+
+		for j, series := range section.ChartSeries {
+			if i >= len(series.Values) || !isChartValue(series.Values[i]) {
+				continue
+			}
+
+			value := series.Values[i]
+			y := valueY(value)
+			bar := sdl.Rect{X: groupX + int32(j)*barWidth, Y: internal.Min32(y, baseline), W: barWidth, H: internal.Abs32(baseline - y)}
+			if barWidth > 4 && seriesCount > 1 {
+				bar.W -= 2 // gap between the bars of a group
+			}
+
+			color := chartSeriesColor(series, j)
+			s.renderer.SetDrawColor(color.R, color.G, color.B, color.A)
+			s.renderer.FillRect(&bar)
+
+			if !section.ChartShowValues {
+				continue
+			}
+			text := internal.FormatChartValue(value)
+			if chartTextWidth(text) > labelWidth {
+				continue
+			}
+			labelY := y - fontHeight - 2
+			if value < 0 {
+				labelY = y + 2
+			}
+			s.renderChartText(text, bar.X+bar.W/2, labelY, constants.TextAlignCenter, s.options.DescriptionColor)
+		}
+	}
+}
+
+func (s *detailScreenState) renderChartLines(section Section, plot sdl.Rect, slot float64, valueY func(float64) int32) {
+	fontHeight := int32(internal.Fonts.TinyFont.Height())
+	showMarkers := slot >= 12
+
+	for j, series := range section.ChartSeries {
+		color := chartSeriesColor(series, j)
+		var previous *sdl.Point
+
+		for i, value := range series.Values {
+			if !isChartValue(value) {
+				previous = nil
+				continue
+			}
+
+			point := sdl.Point{X: plot.X + int32(slot*(float64(i)+0.5)), Y: valueY(value)}
+			s.renderer.SetDrawColor(color.R, color.G, color.B, color.A)
+			if previous != nil {
+				// Two pixels thick
+				s.renderer.DrawLine(previous.X, previous.Y, point.X, point.Y)
+				s.renderer.DrawLine(previous.X, previous.Y+1, point.X, point.Y+1)
+			}
+			if showMarkers {
+				internal.DrawFilledCircle(s.renderer, point.X, point.Y, 3, color)
+			}
+
+			if section.ChartShowValues {
+				text := internal.FormatChartValue(value)
+				if chartTextWidth(text) <= int32(slot) {
+					s.renderChartText(text, point.X, point.Y-fontHeight-4, constants.TextAlignCenter, s.options.DescriptionColor)
+				}
+			}
+
+			previous = &point
+		}
+	}
+}
+
+// renderChartCategoryLabels draws the category labels centered below their slots,
+// skipping enough of them that the rest don't overlap.
+func (s *detailScreenState) renderChartCategoryLabels(section Section, rect, plot sdl.Rect, slot float64) {
+	if len(section.ChartLabels) == 0 {
+		return
+	}
+
+	widest := int32(0)
+	for _, label := range section.ChartLabels {
+		widest = max(widest, chartTextWidth(label))
+	}
+	stride := internal.ChartLabelStride(widest, int32(slot), chartLabelGap)
+
+	y := plot.Y + plot.H + 6
+	for i := 0; i < len(section.ChartLabels); i += stride {
+		label := section.ChartLabels[i]
+		w := chartTextWidth(label)
+
+		// Keep the labels at the ends inside the section
+		x := plot.X + int32(slot*(float64(i)+0.5)) - w/2
+		x = internal.Max32(rect.X, internal.Min32(x, rect.X+rect.W-w))
+		s.renderChartText(label, x, y, constants.TextAlignLeft, chartLabelColor)
+	}
+}
+
+// renderSparkline draws each series as a line across the section, all scaled to the
+// range of every series so they stay comparable, with a dot on the latest value. With
+// ChartShowValues the latest value of the first series is shown at the right.
+func (s *detailScreenState) renderSparkline(section Section, rect sdl.Rect, count int) {
+	fontHeight := int32(internal.Fonts.TinyFont.Height())
+	plot := sdl.Rect{X: rect.X + 3, Y: rect.Y + 3, W: rect.W - 6, H: rect.H - 6}
+
+	if section.ChartShowValues && len(section.ChartSeries) > 0 {
+		values := section.ChartSeries[0].Values
+		for i := len(values) - 1; i >= 0; i-- {
+			if isChartValue(values[i]) {
+				text := internal.FormatChartValue(values[i])
+				s.renderChartText(text, rect.X+rect.W, rect.Y+(rect.H-fontHeight)/2, constants.TextAlignRight, chartSeriesColor(section.ChartSeries[0], 0))
+				plot.W -= chartTextWidth(text) + chartLabelGap
+				break
+			}
+		}
+	}
+	if plot.W <= 0 || plot.H <= 0 {
+		return
+	}
+
+	lo, hi := chartValueRange(section.ChartSeries, false)
+	if hi == lo {
+		lo, hi = lo-1, hi+1
+	}
+
+	pointX := func(i int) int32 {
+		if count == 1 {
+			return plot.X + plot.W/2
+		}
+		return plot.X + int32(float64(plot.W-1)*float64(i)/float64(count-1))
+	}
+	pointY := func(value float64) int32 {
+		return plot.Y + plot.H - 1 - int32(math.Round((value-lo)/(hi-lo)*float64(plot.H-1)))
+	}
+
+	for j, series := range section.ChartSeries {
+		color := chartSeriesColor(series, j)
+		var previous, last *sdl.Point
+
+		for i, value := range series.Values {
+			if !isChartValue(value) {
+				previous = nil
+				continue
+			}
+
+			point := sdl.Point{X: pointX(i), Y: pointY(value)}
+			if previous != nil {
+				s.renderer.SetDrawColor(color.R, color.G, color.B, color.A)
+				s.renderer.DrawLine(previous.X, previous.Y, point.X, point.Y)
+				s.renderer.DrawLine(previous.X, previous.Y+1, point.X, point.Y+1)
+			}
+			previous, last = &point, &point
+		}
+
+		if last != nil {
+			internal.DrawFilledCircle(s.renderer, last.X, last.Y, 3, color)
+		}
+	}
+}
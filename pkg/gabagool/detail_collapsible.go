@@ -0,0 +1,170 @@
+package gabagool
+
+import (
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// SectionExpansion reports whether a collapsible section was left expanded, so the
+// page can be restored the same way next time.
+type SectionExpansion struct {
+	ID       string // Section.SectionID
+	Index    int    // Index into Sections
+	Expanded bool
+}
+
+// sectionExpansionState animates a collapsible section between collapsed and expanded.
+type sectionExpansionState struct {
+	expanded      bool
+	progress      float32 // shown fraction of the content, eased toward expanded
+	contentHeight int32   // full height of the content at the last render
+}
+
+const (
+	sectionExpandSpeed = float32(0.2)
+	sectionChevronSize = int32(12)
+)
+
+func (s *detailScreenState) initializeSectionExpansions() {
+	for i, section := range s.options.Sections {
+		if !section.Collapsible {
+			continue
+		}
+		state := &sectionExpansionState{expanded: !section.Collapsed}
+		if state.expanded {
+			state.progress = 1
+		}
+		s.sectionExpansions[i] = state
+	}
+}
+
+func (s *detailScreenState) collectSectionExpansions() {
+	for i, section := range s.options.Sections {
+		if state, ok := s.sectionExpansions[i]; ok {
+			s.result.SectionExpansions = append(s.result.SectionExpansions, SectionExpansion{
+				ID:       section.SectionID,
+				Index:    i,
+				Expanded: state.expanded,
+			})
+		}
+	}
+}
+
+func (s *detailScreenState) toggleSectionExpansion(sectionIndex int) {
+	if state, ok := s.sectionExpansions[sectionIndex]; ok {
+		state.expanded = !state.expanded
+	}
+}
+
+func (s *detailScreenState) updateSectionExpansions() {
+	for _, state := range s.sectionExpansions {
+		if state.expanded && state.progress < 1 {
+			state.progress += sectionExpandSpeed
+			if state.progress > 1 {
+				state.progress = 1
+			}
+		} else if !state.expanded && state.progress > 0 {
+			state.progress -= sectionExpandSpeed
+			if state.progress < 0 {
+				state.progress = 0
+			}
+		}
+	}
+}
+
+// renderSectionHeader draws the title of a collapsible section behind a chevron
+// showing its state, and registers the header as focusable.
+func (s *detailScreenState) renderSectionHeader(sectionIndex int, state *sectionExpansionState, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
+	var texture *sdl.Texture
+	var titleW, titleH int32
+	if sectionIndex < len(s.sectionTitleTextures) && s.sectionTitleTextures[sectionIndex] != nil {
+		texture = s.sectionTitleTextures[sectionIndex]
+		_, _, titleW, titleH, _ = texture.Query()
+	}
+	if titleH == 0 {
+		titleH = int32(internal.Fonts.MediumFont.Height())
+	}
+
+	rect := sdl.Rect{X: margins.Left, Y: currentY, W: contentWidth, H: titleH}
+	s.focusables = append(s.focusables, detailFocusable{
+		header:       true,
+		sectionIndex: sectionIndex,
+		rect:         sdl.Rect{X: rect.X, Y: rect.Y + s.scrollY, W: rect.W, H: rect.H},
+	})
+
+	if isRectVisible(rect, safeAreaHeight) {
+		if s.focusedHeader == sectionIndex {
+			highlight := sdl.Rect{X: rect.X - 5, Y: rect.Y - 3, W: rect.W + 10, H: rect.H + 6}
+			s.renderer.SetDrawColor(60, 60, 80, 255)
+			s.renderer.FillRect(&highlight)
+			s.renderer.SetDrawColor(100, 100, 200, 255)
+			s.renderer.DrawRect(&highlight)
+		}
+
+		s.renderChevron(margins.Left+sectionChevronSize/2, currentY+titleH/2, state.expanded)
+
+		if texture != nil {
+			titleX := margins.Left + sectionChevronSize + 10
+			titleRect := sdl.Rect{X: titleX, Y: currentY, W: internal.Min32(titleW, contentWidth-(titleX-margins.Left)), H: titleH}
+			s.renderer.Copy(texture, &sdl.Rect{W: titleRect.W, H: titleH}, &titleRect)
+		}
+	}
+
+	return currentY + titleH + 15
+}
+
+// renderChevron draws a triangle centered on (x, y), pointing down when expanded
+// and right when collapsed.
+func (s *detailScreenState) renderChevron(x, y int32, expanded bool) {
+	color := s.options.TitleColor
+	half := sectionChevronSize / 2
+	if expanded {
+		internal.DrawFilledTriangle(s.renderer, x-half, y-half/2, x+half, y-half/2, x, y+half/2, color)
+	} else {
+		internal.DrawFilledTriangle(s.renderer, x-half/2, y-half, x-half/2, y+half, x+half/2, y, color)
+	}
+}
+
+// renderCollapsibleContent draws the divider and content of a collapsible section.
+// While it animates, only the shown fraction is drawn and takes up space, so the
+// sections below and the scroll limits follow along smoothly.
+func (s *detailScreenState) renderCollapsibleContent(sectionIndex int, section Section, state *sectionExpansionState, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
+	if state.progress <= 0 {
+		// Hidden slideshows don't auto-advance
+		if slideshow, ok := s.slideshowStates[sectionIndex]; ok {
+			slideshow.visible = false
+		}
+		return currentY
+	}
+
+	top := currentY
+	animating := state.progress < 1
+	shownHeight := int32(float32(state.contentHeight) * state.progress)
+	firstFocusable := len(s.focusables)
+
+	var restoreClip func()
+	if animating {
+		restoreClip = s.setClip(sdl.Rect{X: 0, Y: top, W: s.window.GetWidth(), H: shownHeight})
+	}
+
+	currentY = s.renderSectionDivider(margins, contentWidth, currentY, safeAreaHeight)
+	currentY = s.renderSectionContent(sectionIndex, section, margins, contentWidth, currentY, safeAreaHeight)
+	state.contentHeight = currentY - top
+
+	if !animating {
+		return currentY
+	}
+	restoreClip()
+
+	// Controls in the hidden part can't take focus
+	shownBottom := top + shownHeight + s.scrollY
+	kept := s.focusables[:firstFocusable]
+	for _, f := range s.focusables[firstFocusable:] {
+		if f.rect.Y+f.rect.H <= shownBottom {
+			kept = append(kept, f)
+		}
+	}
+	s.focusables = kept
+
+	return top + shownHeight
+}
@@ -0,0 +1,83 @@
+package gabagool
+
+import (
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// CustomSection draws content the built-in section types don't cover, such as rating
+// stars or achievement grids. DetailScreen lays it out, scrolls it and skips drawing
+// it while it is off screen, like any other section.
+type CustomSection interface {
+	// Measure returns the height the section needs at the given width. It is called
+	// every frame, so it should be cheap or cached.
+	Measure(width int32) int32
+
+	// Render draws the section inside rect, in screen coordinates. Drawing is clipped
+	// to rect, and Render is only called while part of rect is on screen.
+	Render(renderer *sdl.Renderer, rect sdl.Rect)
+}
+
+// CustomSectionInputHandler is implemented by custom sections that take input. They
+// can take focus like buttons, and receive button presses while focused.
+type CustomSectionInputHandler interface {
+	// HandleInput returns true if the section used the button. Buttons it returns
+	// false for are handled by DetailScreen as usual, so Up and Down should usually
+	// be left alone to let focus move on.
+	HandleInput(button constants.VirtualButton) bool
+}
+
+// NewCustomSection creates a section measured and drawn by custom.
+func NewCustomSection(title string, custom CustomSection) Section {
+	return Section{
+		Type:   SectionTypeCustom,
+		Title:  title,
+		Custom: custom,
+	}
+}
+
+func (s *detailScreenState) renderCustom(sectionIndex int, section Section, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
+	if section.Custom == nil {
+		return currentY
+	}
+
+	height := section.Custom.Measure(contentWidth)
+	if height <= 0 {
+		return currentY
+	}
+
+	rect := sdl.Rect{X: margins.Left, Y: currentY, W: contentWidth, H: height}
+	if _, ok := section.Custom.(CustomSectionInputHandler); ok {
+		s.focusables = append(s.focusables, detailFocusable{
+			custom:       true,
+			sectionIndex: sectionIndex,
+			rect:         sdl.Rect{X: rect.X, Y: rect.Y + s.scrollY, W: rect.W, H: rect.H},
+		})
+	}
+
+	if isRectVisible(rect, safeAreaHeight) {
+		restoreClip := s.setClip(rect)
+		section.Custom.Render(s.renderer, rect)
+		restoreClip()
+
+		if s.focusedCustom == sectionIndex {
+			s.renderer.SetDrawColor(100, 100, 200, 255)
+			s.renderer.DrawRect(&sdl.Rect{X: rect.X - 5, Y: rect.Y - 5, W: rect.W + 10, H: rect.H + 10})
+		}
+	}
+
+	return currentY + height + 15
+}
+
+// handleCustomSectionInput passes a button press to the focused custom section.
+// It returns true if the section used it.
+func (s *detailScreenState) handleCustomSectionInput(button constants.VirtualButton) bool {
+	current := s.focusedIndex()
+	if current < 0 || !s.focusables[current].custom || !s.isOnScreen(s.focusables[current].rect) {
+		return false
+	}
+
+	handler, ok := s.options.Sections[s.focusables[current].sectionIndex].Custom.(CustomSectionInputHandler)
+	return ok && handler.HandleInput(button)
+}
@@ -0,0 +1,367 @@
+package gabagool
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+const (
+	markdownLineGap     = int32(5)
+	markdownQuoteIndent = int32(15)
+	markdownCodePadding = int32(8)
+)
+
+var (
+	markdownCodeBackground  = sdl.Color{R: 50, G: 50, B: 50, A: 255}
+	markdownInlineCodeColor = sdl.Color{R: 70, G: 70, B: 70, A: 255}
+	markdownQuoteBarColor   = sdl.Color{R: 110, G: 110, B: 110, A: 255}
+	markdownRuleColor       = sdl.Color{R: 80, G: 80, B: 80, A: 255}
+)
+
+// markdownFragment is a run of laid out text in a single font and style.
+// Its rect is relative to the top left corner of the section content.
+type markdownFragment struct {
+	text       string
+	font       *ttf.Font
+	style      int
+	color      sdl.Color
+	rect       sdl.Rect
+	inlineCode bool
+	texture    *sdl.Texture
+}
+
+// markdownFill is a solid rectangle behind the text: code blocks, quote bars and rules.
+type markdownFill struct {
+	rect  sdl.Rect
+	color sdl.Color
+}
+
+// markdownFonts are the fonts a Markdown document is set in. Level 3 and lower
+// headings use the body font in bold.
+type markdownFonts struct {
+	body     *ttf.Font
+	heading1 *ttf.Font
+	heading2 *ttf.Font
+}
+
+func defaultMarkdownFonts() markdownFonts {
+	return markdownFonts{
+		body:     internal.Fonts.SmallFont,
+		heading1: internal.Fonts.LargeFont,
+		heading2: internal.Fonts.MediumFont,
+	}
+}
+
+// markdownLayout is a Markdown section laid out for one content width.
+type markdownLayout struct {
+	width     int32
+	height    int32
+	fills     []markdownFill
+	fragments []markdownFragment
+}
+
+// layoutMarkdown positions the blocks of a Markdown document within width.
+// Headings use the title color, everything else the text color.
+func layoutMarkdown(source string, width int32, fonts markdownFonts, textColor, headingColor sdl.Color) *markdownLayout {
+	layout := &markdownLayout{width: width}
+	body := fonts.body
+
+	blocks := internal.ParseMarkdown(source)
+	y, previousBottom := int32(0), int32(0)
+
+	for i, block := range blocks {
+		if i > 0 {
+			y += markdownBlockSpacing(blocks[i-1], block, body)
+		}
+		top := y
+		x := markdownQuoteIndent * int32(block.Quote)
+
+		switch block.Kind {
+		case internal.MarkdownHeading:
+			font, style := fonts.heading(block.Level)
+			y = layout.addRuns(block.Runs, font, style, headingColor, x, y, width-x)
+
+		case internal.MarkdownListItem:
+			x += int32(body.Height()) * int32(block.Depth)
+			markerWidth := internal.Max32(markdownTextWidth(body, ttf.STYLE_NORMAL, "0. "), markdownTextWidth(body, ttf.STYLE_NORMAL, block.Marker+" "))
+			layout.fragments = append(layout.fragments, markdownFragment{
+				text:  block.Marker,
+				font:  body,
+				color: textColor,
+				rect:  sdl.Rect{X: x, Y: y, W: markerWidth, H: int32(body.Height())},
+			})
+			y = layout.addRuns(block.Runs, body, ttf.STYLE_NORMAL, textColor, x+markerWidth, y, width-x-markerWidth)
+
+		case internal.MarkdownCodeBlock:
+			y = layout.addCodeBlock(block, body, textColor, x, y, width-x)
+
+		case internal.MarkdownRule:
+			layout.fills = append(layout.fills, markdownFill{
+				rect:  sdl.Rect{X: x, Y: y + markdownLineGap, W: width - x, H: 2},
+				color: markdownRuleColor,
+			})
+			y += markdownLineGap*2 + 2
+
+		default:
+			y = layout.addRuns(block.Runs, body, ttf.STYLE_NORMAL, textColor, x, y, width-x)
+		}
+
+		// Bars of a quote run continuously through consecutive quoted blocks
+		for level := 1; level <= block.Quote; level++ {
+			barTop := top
+			if i > 0 && blocks[i-1].Quote >= level {
+				barTop = previousBottom
+			}
+			layout.fills = append(layout.fills, markdownFill{
+				rect:  sdl.Rect{X: markdownQuoteIndent * int32(level-1), Y: barTop, W: 3, H: y - barTop},
+				color: markdownQuoteBarColor,
+			})
+		}
+		previousBottom = y
+	}
+
+	layout.height = y
+	return layout
+}
+
+func markdownBlockSpacing(previous, next internal.MarkdownBlock, body *ttf.Font) int32 {
+	if previous.Kind == internal.MarkdownListItem && next.Kind == internal.MarkdownListItem {
+		return markdownLineGap
+	}
+	return int32(body.Height()) / 2
+}
+
+func (f markdownFonts) heading(level int) (*ttf.Font, int) {
+	switch level {
+	case 1:
+		return f.heading1, ttf.STYLE_NORMAL
+	case 2:
+		return f.heading2, ttf.STYLE_NORMAL
+	default:
+		return f.body, ttf.STYLE_BOLD
+	}
+}
+
+func markdownFontStyle(style internal.MarkdownStyle) int {
+	fontStyle := ttf.STYLE_NORMAL
+	if style&internal.MarkdownBold != 0 {
+		fontStyle |= ttf.STYLE_BOLD
+	}
+	if style&internal.MarkdownItalic != 0 {
+		fontStyle |= ttf.STYLE_ITALIC
+	}
+	if style&internal.MarkdownLink != 0 {
+		fontStyle |= ttf.STYLE_UNDERLINE
+	}
+	return fontStyle
+}
+
+// addRuns wraps inline runs into lines starting at (x, y) and returns the Y of the
+// bottom of the last line.
+func (l *markdownLayout) addRuns(runs []internal.MarkdownRun, font *ttf.Font, baseStyle int, color sdl.Color, x, y, width int32) int32 {
+	var text []rune
+	var styles []internal.MarkdownStyle
+	for _, run := range runs {
+		for _, r := range run.Text {
+			text = append(text, r)
+			styles = append(styles, run.Style)
+		}
+	}
+
+	lineHeight := int32(font.Height())
+	if len(text) == 0 {
+		return y + lineHeight
+	}
+
+	// eachStyle calls fn for every stretch of [from, to) that shares one style
+	eachStyle := func(from, to int, fn func(start, end int)) {
+		for start := from; start < to; {
+			end := start + 1
+			for end < to && styles[end] == styles[start] {
+				end++
+			}
+			fn(start, end)
+			start = end
+		}
+	}
+	measure := func(from, to int) int32 {
+		var width int32
+		eachStyle(from, to, func(start, end int) {
+			width += markdownTextWidth(font, baseStyle|markdownFontStyle(styles[start]), string(text[start:end]))
+		})
+		return width
+	}
+
+	for _, span := range internal.WrapSpansFunc(text, width, measure, true) {
+		end := span.End
+		for end > span.Start && unicode.IsSpace(text[end-1]) {
+			end--
+		}
+
+		lineX := x
+		eachStyle(span.Start, end, func(start, end int) {
+			fragment := markdownFragment{
+				text:       string(text[start:end]),
+				font:       font,
+				style:      baseStyle | markdownFontStyle(styles[start]),
+				color:      color,
+				inlineCode: styles[start]&internal.MarkdownCode != 0,
+			}
+			fragment.rect = sdl.Rect{X: lineX, Y: y, W: markdownTextWidth(font, fragment.style, fragment.text), H: lineHeight}
+			l.fragments = append(l.fragments, fragment)
+			lineX += fragment.rect.W
+		})
+		y += lineHeight + markdownLineGap
+	}
+
+	return y - markdownLineGap
+}
+
+// addCodeBlock lays out a code block on a shaded background, keeping its line breaks
+// and indentation, and returns the Y of the bottom of the background.
+func (l *markdownLayout) addCodeBlock(block internal.MarkdownBlock, font *ttf.Font, color sdl.Color, x, y, width int32) int32 {
+	code := ""
+	if len(block.Runs) > 0 {
+		code = strings.ReplaceAll(block.Runs[0].Text, "\t", "    ")
+	}
+
+	text := []rune(code)
+	measure := func(line []rune) int32 {
+		return markdownTextWidth(font, ttf.STYLE_NORMAL, string(line))
+	}
+
+	lineHeight := int32(font.Height())
+	spans := internal.WrapSpans(text, width-markdownCodePadding*2, measure, true)
+	height := int32(len(spans))*(lineHeight+markdownLineGap) - markdownLineGap + markdownCodePadding*2
+
+	l.fills = append(l.fills, markdownFill{
+		rect:  sdl.Rect{X: x, Y: y, W: width, H: height},
+		color: markdownCodeBackground,
+	})
+
+	lineY := y + markdownCodePadding
+	for _, span := range spans {
+		line := strings.TrimRight(string(text[span.Start:span.End]), " ")
+		if line != "" {
+			l.fragments = append(l.fragments, markdownFragment{
+				text:  line,
+				font:  font,
+				color: color,
+				rect:  sdl.Rect{X: x + markdownCodePadding, Y: lineY, W: measure([]rune(line)), H: lineHeight},
+			})
+		}
+		lineY += lineHeight + markdownLineGap
+	}
+
+	return y + height
+}
+
+// withFontStyle runs fn with style applied to a shared font and restores the previous style.
+func withFontStyle(font *ttf.Font, style int, fn func()) {
+	if previous := font.GetStyle(); previous != style {
+		font.SetStyle(style)
+		defer font.SetStyle(previous)
+	}
+	fn()
+}
+
+func markdownTextWidth(font *ttf.Font, style int, text string) int32 {
+	var width int
+	withFontStyle(font, style, func() {
+		width, _, _ = font.SizeUTF8(text)
+	})
+	return int32(width)
+}
+
+func (f *markdownFragment) ensureTexture(renderer *sdl.Renderer) *sdl.Texture {
+	if f.texture == nil {
+		withFontStyle(f.font, f.style, func() {
+			f.texture = renderText(renderer, f.text, f.font, f.color)
+		})
+	}
+	return f.texture
+}
+
+// render draws the fragment at rect, over a shaded background if it is inline code.
+func (f *markdownFragment) render(renderer *sdl.Renderer, rect sdl.Rect) {
+	if f.inlineCode {
+		background := sdl.Rect{X: rect.X - 2, Y: rect.Y, W: rect.W + 4, H: rect.H}
+		renderer.SetDrawColor(markdownInlineCodeColor.R, markdownInlineCodeColor.G, markdownInlineCodeColor.B, markdownInlineCodeColor.A)
+		renderer.FillRect(&background)
+	}
+
+	texture := f.ensureTexture(renderer)
+	if texture == nil {
+		return
+	}
+	_, _, w, h, err := texture.Query()
+	if err != nil {
+		return
+	}
+	renderer.Copy(texture, nil, &sdl.Rect{X: rect.X, Y: rect.Y, W: w, H: h})
+}
+
+func (f *markdownFragment) releaseTexture() {
+	if f.texture != nil {
+		f.texture.Destroy()
+		f.texture = nil
+	}
+}
+
+func (l *markdownLayout) destroy() {
+	if l == nil {
+		return
+	}
+	for i := range l.fragments {
+		l.fragments[i].releaseTexture()
+	}
+}
+
+func (s *detailScreenState) renderMarkdown(sectionIndex int, section Section, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
+	if section.Description == "" {
+		return currentY
+	}
+
+	// Same inset as description sections to keep text clear of the scrollbar
+	markdownPadding := int32(15)
+	originX := margins.Left + markdownPadding
+	width := contentWidth - (markdownPadding * 2)
+
+	layout := s.markdownLayouts[sectionIndex]
+	if layout == nil || layout.width != width {
+		layout.destroy()
+		layout = layoutMarkdown(section.Description, width, defaultMarkdownFonts(), s.options.DescriptionColor, s.options.TitleColor)
+		s.markdownLayouts[sectionIndex] = layout
+	}
+
+	for _, fill := range layout.fills {
+		rect := fill.rect
+		rect.X += originX
+		rect.Y += currentY
+		if isRectVisible(rect, safeAreaHeight) {
+			s.renderer.SetDrawColor(fill.color.R, fill.color.G, fill.color.B, fill.color.A)
+			s.renderer.FillRect(&rect)
+		}
+	}
+
+	for i := range layout.fragments {
+		fragment := &layout.fragments[i]
+		rect := fragment.rect
+		rect.X += originX
+		rect.Y += currentY
+
+		// Only keep textures for text on screen so long documents don't hold every line in video memory
+		if !isRectVisible(rect, safeAreaHeight) {
+			fragment.releaseTexture()
+			continue
+		}
+
+		fragment.render(s.renderer, rect)
+	}
+
+	return currentY + layout.height + 15
+}
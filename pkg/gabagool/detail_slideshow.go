@@ -0,0 +1,189 @@
+package gabagool
+
+import (
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// slideshowSlide is one image of a slideshow, image or QR code section.
+type slideshowSlide struct {
+	path    string // empty for generated images such as QR codes
+	caption string
+	texture *sdl.Texture
+	rect    sdl.Rect // reserved from the image header until the slide loads
+	loaded  bool     // loading was attempted; texture is nil if it failed
+}
+
+// available reports whether the slide can be shown: it loaded, or hasn't been tried yet.
+func (slide slideshowSlide) available() bool {
+	return !slide.loaded || slide.texture != nil
+}
+
+func (state *slideshowState) availableCount() int {
+	count := 0
+	for _, slide := range state.slides {
+		if slide.available() {
+			count++
+		}
+	}
+	return count
+}
+
+// reservedSlideRect is the space a slide takes before its image loads, so the layout
+// doesn't shift when it does: the size from the image header, scaled the way
+// loadAndScaleImage scales it, or the whole image area if the header can't be read.
+func (s *detailScreenState) reservedSlideRect(path string, maxWidth, maxHeight int32, section Section) sdl.Rect {
+	imageW, imageH := maxWidth, maxHeight
+	if file, err := os.Open(path); err == nil {
+		config, _, err := image.DecodeConfig(file)
+		file.Close()
+		if err == nil && config.Width > 0 && config.Height > 0 {
+			imageW, imageH = s.calculateScaledDimensions(int32(config.Width), int32(config.Height), maxWidth, maxHeight)
+		}
+	}
+	return sdl.Rect{X: s.calculateImageX(imageW, section), W: imageW, H: imageH}
+}
+
+func (s *detailScreenState) loadSlide(sectionIndex int, state *slideshowState, index int) {
+	slide := &state.slides[index]
+	if slide.loaded {
+		return
+	}
+	slide.loaded = true
+	slide.texture, slide.rect = s.loadAndScaleImage(slide.path, state.maxWidth, state.maxHeight, s.options.Sections[sectionIndex])
+}
+
+// prepareSlides loads the current slide once the section is within a screen of the
+// viewport, moving past images that fail to load, then preloads one neighbour per
+// frame so paging doesn't stall on decoding.
+func (s *detailScreenState) prepareSlides(sectionIndex int, state *slideshowState, currentY int32, safeAreaHeight int32) {
+	count := len(state.slides)
+	if count == 0 || currentY > safeAreaHeight*2 || currentY+state.maxHeight < -safeAreaHeight {
+		return
+	}
+
+	for range count {
+		s.loadSlide(sectionIndex, state, state.currentIndex)
+		if state.slides[state.currentIndex].texture != nil {
+			break
+		}
+		state.currentIndex = (state.currentIndex + 1) % count
+	}
+
+	for _, index := range []int{(state.currentIndex + 1) % count, (state.currentIndex - 1 + count) % count} {
+		if !state.slides[index].loaded {
+			s.loadSlide(sectionIndex, state, index)
+			break
+		}
+	}
+}
+
+// advanceSlideshow moves step slides forward or back, skipping images that fail to
+// load, and starts the crossfade from the slide being left.
+func (s *detailScreenState) advanceSlideshow(sectionIndex int, state *slideshowState, step int) {
+	count := len(state.slides)
+	index := state.currentIndex
+	for range count {
+		index = (index + step + count) % count
+		s.loadSlide(sectionIndex, state, index)
+		if state.slides[index].texture != nil {
+			break
+		}
+	}
+
+	state.lastAdvance = time.Now()
+	if index == state.currentIndex || state.slides[index].texture == nil {
+		return
+	}
+
+	state.previousIndex = state.currentIndex
+	state.transitionStart = time.Now()
+	state.currentIndex = index
+}
+
+// updateSlideshows auto-advances slideshows on screen. Any input holds them for a
+// full interval, so a slide doesn't change while the user is reading or navigating.
+func (s *detailScreenState) updateSlideshows() {
+	for sectionIndex, state := range s.slideshowStates {
+		interval := s.options.Sections[sectionIndex].SlideInterval
+		if interval <= 0 {
+			continue
+		}
+		if !state.visible {
+			state.lastAdvance = time.Now()
+			continue
+		}
+		if time.Since(state.lastAdvance) >= interval && time.Since(s.lastInputTime) >= interval {
+			s.advanceSlideshow(sectionIndex, state, 1)
+		}
+	}
+}
+
+// renderSlide draws the current slide at currentY, fading it in over the previous one
+// while a crossfade is running.
+func (s *detailScreenState) renderSlide(sectionIndex int, state *slideshowState, currentY int32) {
+	slide := state.slides[state.currentIndex]
+	rect := slide.rect
+	rect.Y = currentY
+
+	crossfade := s.options.Sections[sectionIndex].SlideCrossfade
+	progress := 1.0
+	if state.previousIndex >= 0 && crossfade > 0 {
+		progress = float64(time.Since(state.transitionStart)) / float64(crossfade)
+	}
+	if progress >= 1 {
+		state.previousIndex = -1
+		s.renderer.Copy(slide.texture, nil, &rect)
+		return
+	}
+
+	// The previous slide stays opaque under a slide of the same size, and fades out
+	// where it would otherwise stick out from behind it
+	if previous := state.slides[state.previousIndex]; previous.texture != nil {
+		previousRect := previous.rect
+		previousRect.Y = currentY
+		alpha := uint8(255)
+		if previousRect != rect {
+			alpha = uint8(255 * (1 - progress))
+		}
+		previous.texture.SetBlendMode(sdl.BLENDMODE_BLEND)
+		previous.texture.SetAlphaMod(alpha)
+		s.renderer.Copy(previous.texture, nil, &previousRect)
+		previous.texture.SetAlphaMod(255)
+	}
+
+	slide.texture.SetBlendMode(sdl.BLENDMODE_BLEND)
+	slide.texture.SetAlphaMod(uint8(255 * progress))
+	s.renderer.Copy(slide.texture, nil, &rect)
+	slide.texture.SetAlphaMod(255)
+}
+
+func (s *detailScreenState) renderSlideCaption(slide slideshowSlide, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
+	if slide.caption == "" {
+		return currentY
+	}
+
+	captionHeight := calculateMultilineTextHeight(slide.caption, internal.Fonts.SmallFont, contentWidth)
+	if isRectVisible(sdl.Rect{X: margins.Left, Y: currentY, W: contentWidth, H: captionHeight}, safeAreaHeight) {
+		internal.RenderMultilineTextWithCache(
+			s.renderer,
+			slide.caption,
+			internal.Fonts.SmallFont,
+			contentWidth,
+			margins.Left,
+			currentY,
+			s.options.DescriptionColor,
+			constants.TextAlignCenter,
+			s.textureCache)
+	}
+
+	return currentY + captionHeight
+}
@@ -0,0 +1,435 @@
+package gabagool
+
+import (
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// TableColumn sizes and aligns a table column. Columns without an entry fit their
+// content and align left.
+type TableColumn struct {
+	Width     int32               // Fixed width in pixels, cell padding included (0 = fit content)
+	MaxWidth  int32               // Widest a fitted column grows before its text wraps (0 = no limit)
+	Alignment constants.TextAlign // Alignment of the header and cells
+	Sortable  bool                // Allow sorting by this column with Select (tables with a TableID only)
+}
+
+// TableSelection represents the selected row of a table with a TableID.
+type TableSelection struct {
+	ID    string
+	Index int // Index into TableRows
+	Row   TableRow
+}
+
+// tableState is the interactive state of a table with a TableID.
+type tableState struct {
+	selectedRow    int // Index into TableRows, -1 = none
+	column         int // Column under the cursor while a row has focus
+	columns        int // Column count from the last render
+	scrollX        int32
+	sortColumn     int // -1 = unsorted
+	sortDescending bool
+	order          []int // Display order of TableRows, nil = as given
+}
+
+const tableSortIndicatorWidth = int32(14)
+
+func (s *detailScreenState) initializeTables() {
+	for _, section := range s.options.Sections {
+		if section.Type == SectionTypeTable && section.TableID != "" {
+			s.tableStates[section.TableID] = &tableState{
+				selectedRow: -1,
+				sortColumn:  -1,
+			}
+		}
+	}
+}
+
+func (s *detailScreenState) collectTableSelections() {
+	for _, section := range s.options.Sections {
+		if section.Type != SectionTypeTable || section.TableID == "" {
+			continue
+		}
+		if state, ok := s.tableStates[section.TableID]; ok && state.selectedRow >= 0 && state.selectedRow < len(section.TableRows) {
+			s.result.TableSelections = append(s.result.TableSelections, TableSelection{
+				ID:    section.TableID,
+				Index: state.selectedRow,
+				Row:   section.TableRows[state.selectedRow],
+			})
+		}
+	}
+}
+
+func tableColumn(section Section, col int) TableColumn {
+	if col < len(section.TableColumns) {
+		return section.TableColumns[col]
+	}
+	return TableColumn{}
+}
+
+// focusedTable returns the table section and state of the focused row, if a row on screen has focus.
+func (s *detailScreenState) focusedTable() (*Section, *tableState) {
+	current := s.focusedIndex()
+	if current < 0 || s.focusedTableID == "" || !s.isOnScreen(s.focusables[current].rect) {
+		return nil, nil
+	}
+	for i := range s.options.Sections {
+		section := &s.options.Sections[i]
+		if section.Type == SectionTypeTable && section.TableID == s.focusedTableID {
+			return section, s.tableStates[section.TableID]
+		}
+	}
+	return nil, nil
+}
+
+// moveTableColumn moves the column cursor of the focused table, scrolling it
+// sideways when its columns overflow. It returns false when no table row has focus.
+func (s *detailScreenState) moveTableColumn(left bool) bool {
+	_, state := s.focusedTable()
+	if state == nil {
+		return false
+	}
+
+	if left {
+		state.column = max(state.column-1, 0)
+	} else {
+		state.column = min(state.column+1, max(state.columns-1, 0))
+	}
+	return true
+}
+
+// toggleTableSort cycles the column under the cursor through ascending, descending
+// and unsorted. It returns false when no sortable table column has focus.
+func (s *detailScreenState) toggleTableSort() bool {
+	section, state := s.focusedTable()
+	if state == nil || !tableColumn(*section, state.column).Sortable {
+		return false
+	}
+
+	switch {
+	case state.sortColumn != state.column:
+		state.sortColumn = state.column
+		state.sortDescending = false
+	case !state.sortDescending:
+		state.sortDescending = true
+	default:
+		state.sortColumn = -1
+		state.order = nil
+		return true
+	}
+
+	cells := make([][]string, len(section.TableRows))
+	for i, row := range section.TableRows {
+		cells[i] = row.Cells
+	}
+	state.order = internal.SortTableRows(cells, state.sortColumn, state.sortDescending)
+	return true
+}
+
+// toggleTableRowSelection selects the row, or clears the selection if it was already selected.
+func (s *detailScreenState) toggleTableRowSelection(tableID string, row int) {
+	state, ok := s.tableStates[tableID]
+	if !ok {
+		return
+	}
+	if state.selectedRow == row {
+		state.selectedRow = -1
+	} else {
+		state.selectedRow = row
+	}
+}
+
+// scrollColumnIntoView adjusts the horizontal scroll so the cursor column is visible
+// and returns the new offset.
+func (t *tableState) scrollColumnIntoView(widths []int32, viewWidth int32) int32 {
+	var start, total int32
+	for i, w := range widths {
+		if i < t.column {
+			start += w
+		}
+		total += w
+	}
+	end := start + widths[t.column]
+
+	if end > t.scrollX+viewWidth {
+		t.scrollX = end - viewWidth
+	}
+	if start < t.scrollX {
+		t.scrollX = start
+	}
+	t.scrollX = internal.Max32(0, internal.Min32(t.scrollX, total-viewWidth))
+	return t.scrollX
+}
+
+// tableColumnWidths measures each column and sizes it with its TableColumn hints.
+// Interactive tables keep columns at their natural width and scroll sideways when
+// they overflow; others squeeze the columns into contentWidth.
+func (s *detailScreenState) tableColumnWidths(section Section, numCols int, cellPaddingX, contentWidth int32, interactive bool) []int32 {
+	font := internal.Fonts.SmallFont
+
+	natural := make([]int32, numCols)
+	fixed := make([]int32, numCols)
+	for col := 0; col < numCols; col++ {
+		column := tableColumn(section, col)
+
+		// Measure header
+		if col < len(section.TableHeaders) && section.TableHeaders[col] != "" {
+			w, _, err := font.SizeUTF8(section.TableHeaders[col])
+			if err == nil {
+				natural[col] = int32(w)
+				if interactive && column.Sortable {
+					natural[col] += tableSortIndicatorWidth
+				}
+			}
+		}
+		// Measure all rows
+		for _, row := range section.TableRows {
+			if col < len(row.Cells) && row.Cells[col] != "" {
+				w, _, err := font.SizeUTF8(row.Cells[col])
+				if err == nil && int32(w) > natural[col] {
+					natural[col] = int32(w)
+				}
+			}
+		}
+		natural[col] += cellPaddingX * 2
+
+		if column.MaxWidth > 0 {
+			natural[col] = internal.Min32(natural[col], column.MaxWidth)
+		}
+		fixed[col] = column.Width
+	}
+
+	return internal.TableColumnWidths(natural, fixed, contentWidth, cellPaddingX*2+10, interactive)
+}
+
+func (s *detailScreenState) renderTable(section Section, margins internal.Padding, contentWidth, currentY int32, safeAreaHeight int32) int32 {
+	if len(section.TableRows) == 0 {
+		return currentY
+	}
+
+	font := internal.Fonts.SmallFont
+	headerFont := internal.Fonts.SmallFont
+
+	_, fontHeight, err := font.SizeUTF8("Aj")
+	if err != nil {
+		fontHeight = 20
+	}
+	headerFontHeight := fontHeight
+
+	cellPaddingX := int32(8)
+	cellPaddingY := int32(6)
+
+	// Determine column count from headers or first row
+	numCols := len(section.TableHeaders)
+	for _, row := range section.TableRows {
+		if len(row.Cells) > numCols {
+			numCols = len(row.Cells)
+		}
+	}
+	if numCols == 0 {
+		return currentY
+	}
+
+	// Tables with an ID take focus, sort and scroll sideways
+	state := s.tableStates[section.TableID]
+	colWidths := s.tableColumnWidths(section, numCols, cellPaddingX, contentWidth, state != nil)
+
+	tableWidth := int32(0)
+	for _, w := range colWidths {
+		tableWidth += w
+	}
+	rowWidth := internal.Max32(tableWidth, contentWidth)
+
+	scrollX := int32(0)
+	isTableFocused := false
+	if state != nil {
+		state.columns = numCols
+		state.column = min(state.column, numCols-1)
+		scrollX = state.scrollColumnIntoView(colWidths, contentWidth)
+		isTableFocused = s.focusedTableID == section.TableID
+	}
+
+	// Keep columns scrolled out of the content area from drawing over the margins
+	defer s.setClip(sdl.Rect{X: margins.Left, Y: 0, W: contentWidth, H: safeAreaHeight})()
+
+	tableX := margins.Left - scrollX
+	hasHeaders := len(section.TableHeaders) > 0
+
+	// Render header row
+	if hasHeaders {
+		headerHeight := int32(headerFontHeight) + cellPaddingY*2
+		headerRect := sdl.Rect{X: tableX, Y: currentY, W: rowWidth, H: headerHeight}
+
+		if isRectVisible(headerRect, safeAreaHeight) {
+			// Draw header background
+			s.renderer.SetDrawColor(50, 50, 50, 255)
+			s.renderer.FillRect(&headerRect)
+
+			cellX := tableX
+			for col := 0; col < numCols; col++ {
+				column := tableColumn(section, col)
+				maxCellWidth := colWidths[col] - cellPaddingX*2
+				sorted := state != nil && state.sortColumn == col
+
+				// Highlight the column under the cursor
+				if isTableFocused && col == state.column {
+					s.renderer.SetDrawColor(60, 60, 80, 255)
+					s.renderer.FillRect(&sdl.Rect{X: cellX, Y: currentY, W: colWidths[col], H: headerHeight})
+				}
+
+				if state != nil && column.Sortable {
+					maxCellWidth -= tableSortIndicatorWidth
+				}
+
+				if col < len(section.TableHeaders) && section.TableHeaders[col] != "" {
+					internal.RenderMultilineTextWithCache(
+						s.renderer,
+						section.TableHeaders[col],
+						headerFont,
+						maxCellWidth,
+						cellX+cellPaddingX,
+						currentY+cellPaddingY,
+						s.options.TitleColor,
+						column.Alignment,
+						s.textureCache)
+				}
+
+				if sorted {
+					s.renderSortIndicator(cellX+colWidths[col]-cellPaddingX-tableSortIndicatorWidth/2, currentY+headerHeight/2, state.sortDescending)
+				}
+
+				// Draw vertical lines for Full grid
+				if section.TableGrid == TableGridFull && col > 0 {
+					s.renderer.SetDrawColor(80, 80, 80, 255)
+					s.renderer.DrawLine(cellX, currentY, cellX, currentY+headerHeight)
+				}
+
+				cellX += colWidths[col]
+			}
+
+			// Draw bottom border for header
+			if section.TableGrid != TableGridNone {
+				s.renderer.SetDrawColor(80, 80, 80, 255)
+				s.renderer.DrawLine(tableX, currentY+headerHeight, tableX+rowWidth, currentY+headerHeight)
+			}
+		}
+
+		currentY += int32(headerFontHeight) + cellPaddingY*2
+	}
+
+	order := make([]int, len(section.TableRows))
+	for i := range order {
+		order[i] = i
+	}
+	if state != nil && state.order != nil {
+		order = state.order
+	}
+
+	// Render data rows
+	for displayIdx, rowIdx := range order {
+		row := section.TableRows[rowIdx]
+
+		// Calculate row height based on tallest cell
+		rowHeight := int32(fontHeight) + cellPaddingY*2
+		for col := 0; col < numCols && col < len(row.Cells); col++ {
+			if row.Cells[col] != "" {
+				maxCellWidth := colWidths[col] - cellPaddingX*2
+				if maxCellWidth < 10 {
+					maxCellWidth = 10
+				}
+				cellHeight := calculateMultilineTextHeight(row.Cells[col], font, maxCellWidth) - 20 // subtract trailing padding baked into calculateMultilineTextHeight
+				cellHeightWithPadding := cellHeight + cellPaddingY*2
+				if cellHeightWithPadding > rowHeight {
+					rowHeight = cellHeightWithPadding
+				}
+			}
+		}
+
+		rowRect := sdl.Rect{X: tableX, Y: currentY, W: rowWidth, H: rowHeight}
+		viewRect := sdl.Rect{X: margins.Left, Y: currentY, W: contentWidth, H: rowHeight}
+		isFocused := isTableFocused && s.focusedTableRow == rowIdx
+
+		if state != nil {
+			s.focusables = append(s.focusables, detailFocusable{
+				tableID: section.TableID,
+				row:     rowIdx,
+				rect:    sdl.Rect{X: viewRect.X, Y: viewRect.Y + s.scrollY, W: viewRect.W, H: viewRect.H},
+			})
+		}
+
+		if isRectVisible(rowRect, safeAreaHeight) {
+			// Alternating row backgrounds
+			if section.TableGrid == TableGridAlternatingRows && displayIdx%2 == 1 {
+				s.renderer.SetDrawColor(35, 35, 35, 255)
+				s.renderer.FillRect(&rowRect)
+			}
+
+			if isFocused {
+				s.renderer.SetDrawColor(60, 60, 80, 255)
+				s.renderer.FillRect(&rowRect)
+			}
+
+			// Mark the selected row with a bar along its left edge
+			if state != nil && state.selectedRow == rowIdx {
+				s.renderer.SetDrawColor(100, 200, 100, 255)
+				s.renderer.FillRect(&sdl.Rect{X: margins.Left, Y: currentY, W: 4, H: rowHeight})
+			}
+
+			cellX := tableX
+			for col := 0; col < numCols; col++ {
+				if col < len(row.Cells) && row.Cells[col] != "" {
+					textX := cellX + cellPaddingX
+					textY := currentY + cellPaddingY
+					maxCellWidth := colWidths[col] - cellPaddingX*2
+
+					internal.RenderMultilineTextWithCache(
+						s.renderer,
+						row.Cells[col],
+						font,
+						maxCellWidth,
+						textX,
+						textY,
+						s.options.DescriptionColor,
+						tableColumn(section, col).Alignment,
+						s.textureCache)
+				}
+
+				// Draw vertical lines for Full grid
+				if section.TableGrid == TableGridFull && col > 0 {
+					s.renderer.SetDrawColor(80, 80, 80, 255)
+					s.renderer.DrawLine(cellX, currentY, cellX, currentY+rowHeight)
+				}
+
+				cellX += colWidths[col]
+			}
+
+			// Draw row divider
+			if section.TableGrid == TableGridRowDividers || section.TableGrid == TableGridFull {
+				s.renderer.SetDrawColor(80, 80, 80, 255)
+				s.renderer.DrawLine(tableX, currentY+rowHeight, tableX+rowWidth, currentY+rowHeight)
+			}
+
+			if isFocused {
+				s.renderer.SetDrawColor(100, 100, 200, 255)
+				s.renderer.DrawRect(&viewRect)
+			}
+		}
+
+		currentY += rowHeight
+	}
+
+	return currentY + 15
+}
+
+// renderSortIndicator draws a small triangle centered on (x, y), pointing up for
+// ascending and down for descending order.
+func (s *detailScreenState) renderSortIndicator(x, y int32, descending bool) {
+	color := sdl.Color{R: 200, G: 200, B: 200, A: 255}
+	half := tableSortIndicatorWidth / 3
+	if descending {
+		internal.DrawFilledTriangle(s.renderer, x-half, y-half/2, x+half, y-half/2, x, y+half/2, color)
+	} else {
+		internal.DrawFilledTriangle(s.renderer, x-half, y+half/2, x+half, y+half/2, x, y-half/2, color)
+	}
+}
@@ -0,0 +1,73 @@
+package gabagool
+
+import (
+	"testing"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func TestSelectSortsFocusedTable(t *testing.T) {
+	tests := []struct {
+		name          string
+		actionButton  constants.VirtualButton
+		confirmButton constants.VirtualButton
+		wantSorted    bool
+		wantAction    DetailAction
+	}{
+		{
+			name:          "select is free",
+			actionButton:  constants.VirtualButtonY,
+			confirmButton: constants.VirtualButtonA,
+			wantSorted:    true,
+		},
+		{
+			name:          "select is the action button",
+			actionButton:  constants.VirtualButtonSelect,
+			confirmButton: constants.VirtualButtonA,
+			wantAction:    DetailActionTriggered,
+		},
+		{
+			name:          "select is the confirm button",
+			actionButton:  constants.VirtualButtonY,
+			confirmButton: constants.VirtualButtonSelect,
+			wantAction:    DetailActionConfirmed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &detailScreenState{
+				options: DetailScreenOptions{
+					Sections: []Section{{
+						Type:         SectionTypeTable,
+						TableID:      "games",
+						TableColumns: []TableColumn{{Sortable: true}},
+						TableRows:    []TableRow{{Cells: []string{"Zelda"}}, {Cells: []string{"Mario"}}},
+					}},
+					AllowAction:   true,
+					ActionButton:  tt.actionButton,
+					ConfirmButton: tt.confirmButton,
+				},
+				tableStates:          map[string]*tableState{"games": {selectedRow: -1, sortColumn: -1}},
+				focusedButtonSection: -1,
+				focusedHeader:        -1,
+				focusedCustom:        -1,
+				focusedTableID:       "games",
+				visibleImageSection:  -1,
+				focusables:           []detailFocusable{{tableID: "games", rect: sdl.Rect{H: 20}}},
+				viewportHeight:       100,
+			}
+
+			s.handleInputEvent(&internal.Event{Button: constants.VirtualButtonSelect, Pressed: true})
+
+			if sorted := s.tableStates["games"].sortColumn == 0; sorted != tt.wantSorted {
+				t.Errorf("sorted = %v, want %v", sorted, tt.wantSorted)
+			}
+			if s.result.Action != tt.wantAction {
+				t.Errorf("Action = %v, want %v", s.result.Action, tt.wantAction)
+			}
+		})
+	}
+}
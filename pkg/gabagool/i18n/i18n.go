@@ -13,6 +13,7 @@ var i *I18N
 type I18N struct {
 	localizer *i18n.Localizer
 	bundle    *i18n.Bundle
+	language  language.Tag
 }
 
 type MessageFile struct {
@@ -34,7 +35,7 @@ func InitI18N(messageFilePaths []string) error {
 
 	localizer := i18n.NewLocalizer(bundle, language.English.String(), language.Spanish.String())
 
-	i = &I18N{localizer: localizer, bundle: bundle}
+	i = &I18N{localizer: localizer, bundle: bundle, language: language.English}
 
 	return nil
 }
@@ -53,7 +54,7 @@ func InitI18NFromBytes(messageFiles []MessageFile) error {
 
 	localizer := i18n.NewLocalizer(bundle, language.English.String(), language.Spanish.String())
 
-	i = &I18N{localizer: localizer, bundle: bundle}
+	i = &I18N{localizer: localizer, bundle: bundle, language: language.English}
 
 	return nil
 }
@@ -61,7 +62,16 @@ func InitI18NFromBytes(messageFiles []MessageFile) error {
 func SetLanguage(lang language.Tag) {
 	localizer := i18n.NewLocalizer(i.bundle, lang.String())
 
-	i = &I18N{localizer: localizer, bundle: i.bundle}
+	i = &I18N{localizer: localizer, bundle: i.bundle, language: lang}
+}
+
+// CurrentLanguage returns the active language tag.
+// English is returned if i18n has not been initialized.
+func CurrentLanguage() language.Tag {
+	if i == nil {
+		return language.English
+	}
+	return i.language
 }
 
 func SetWithCode(code string) error {
@@ -0,0 +1,397 @@
+package gabagool
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/img"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ImageViewerZoom sets how ImageViewer sizes an image on screen.
+type ImageViewerZoom int
+
+const (
+	ImageViewerZoomFit    ImageViewerZoom = iota // Whole image on screen (default)
+	ImageViewerZoomFill                          // Image covers the screen, the overflow can be panned
+	ImageViewerZoomActual                        // One image pixel per screen pixel
+)
+
+// ImageViewerOptions configures the ImageViewer component.
+type ImageViewerOptions struct {
+	StartIndex      int              // Image shown first
+	Zoom            ImageViewerZoom  // Initial zoom
+	ShowInfo        bool             // Show the file name and dimensions overlay when opened
+	BackgroundColor sdl.Color        // Color behind the image (default: black)
+	FooterHelpItems []FooterHelpItem // Shown with the info overlay (default: the viewer controls)
+}
+
+// ImageViewerResult represents the result of the ImageViewer component.
+type ImageViewerResult struct {
+	Index int // Image showing when the viewer was closed
+}
+
+const (
+	imageViewerPanSpeed      = 600.0  // D-pad pan speed in pixels per second
+	imageViewerStickSpeed    = 1200.0 // Pan speed at full stick deflection
+	imageViewerStickDeadzone = 8000
+)
+
+type imageViewerState struct {
+	window     *internal.Window
+	renderer   *sdl.Renderer
+	options    ImageViewerOptions
+	imagePaths []string
+
+	index        int
+	texture      *sdl.Texture
+	imageW       int32 // Dimensions of the file, the texture may be smaller
+	imageH       int32
+	zoom         ImageViewerZoom
+	quarterTurns int
+	panX, panY   float64
+	showInfo     bool
+
+	held struct {
+		up, down, left, right bool
+	}
+	stickX, stickY int16
+
+	textureCache  *internal.TextureCache
+	lastInputTime time.Time
+	inputDelay    time.Duration
+	lastFrame     time.Time
+	done          bool
+}
+
+// ImageViewer shows images full screen, one at a time. A cycles the zoom between fit,
+// fill and 1:1, X rotates, Y toggles the info overlay and L1/R1 page through the set.
+// The D-pad and right stick pan images larger than the screen; when an image fits
+// across, left/right page instead. B closes the viewer and the result reports the
+// image that was showing.
+func ImageViewer(imagePaths []string, options ImageViewerOptions) (*ImageViewerResult, error) {
+	if len(imagePaths) == 0 {
+		return nil, errors.New("image viewer requires at least one image")
+	}
+
+	window := internal.GetWindow()
+	state := &imageViewerState{
+		window:        window,
+		renderer:      window.Renderer,
+		options:       options,
+		imagePaths:    imagePaths,
+		index:         max(0, min(options.StartIndex, len(imagePaths)-1)),
+		zoom:          options.Zoom,
+		showInfo:      options.ShowInfo,
+		textureCache:  internal.NewTextureCache(),
+		lastInputTime: time.Now(),
+		inputDelay:    constants.DefaultInputDelay,
+		lastFrame:     time.Now(),
+	}
+	if state.options.BackgroundColor.A == 0 {
+		state.options.BackgroundColor = sdl.Color{R: 0, G: 0, B: 0, A: 255}
+	}
+	if state.options.FooterHelpItems == nil {
+		state.options.FooterHelpItems = []FooterHelpItem{
+			{ButtonName: "B", HelpText: "Close"},
+			{ButtonName: "A", HelpText: "Zoom"},
+			{ButtonName: "X", HelpText: "Rotate"},
+			{ButtonName: "Y", HelpText: "Info"},
+		}
+	}
+	defer state.cleanup()
+
+	state.loadImage()
+	for !state.done {
+		state.handleEvents()
+		state.update()
+		state.render()
+	}
+
+	return &ImageViewerResult{Index: state.index}, nil
+}
+
+func (s *imageViewerState) cleanup() {
+	if s.texture != nil {
+		s.texture.Destroy()
+		s.texture = nil
+	}
+	if s.textureCache != nil {
+		s.textureCache.Destroy()
+	}
+}
+
+// loadImage replaces the texture with the current image at full resolution, unless
+// it is larger than the renderer supports.
+func (s *imageViewerState) loadImage() {
+	if s.texture != nil {
+		s.texture.Destroy()
+		s.texture = nil
+	}
+	s.imageW, s.imageH = 0, 0
+	s.quarterTurns = 0
+	s.panX, s.panY = 0, 0
+
+	path := s.imagePaths[s.index]
+	image, err := img.Load(path)
+	if err != nil || image == nil {
+		internal.GetInternalLogger().Error("Failed to load image", "path", path, "error", err)
+		return
+	}
+	defer image.Free()
+
+	s.imageW, s.imageH = image.W, image.H
+
+	textureSurface := image
+	if info, err := s.renderer.GetInfo(); err == nil && info.MaxTextureWidth > 0 && info.MaxTextureHeight > 0 &&
+		(image.W > info.MaxTextureWidth || image.H > info.MaxTextureHeight) {
+		scale := math.Min(float64(info.MaxTextureWidth)/float64(image.W), float64(info.MaxTextureHeight)/float64(image.H))
+		scaledW, scaledH := int32(float64(image.W)*scale), int32(float64(image.H)*scale)
+		if scaled, err := sdl.CreateRGBSurfaceWithFormat(0, scaledW, scaledH, 32, image.Format.Format); err == nil {
+			if err := image.BlitScaled(nil, scaled, &sdl.Rect{W: scaledW, H: scaledH}); err == nil {
+				textureSurface = scaled
+			}
+			defer scaled.Free()
+		}
+	}
+
+	texture, err := s.renderer.CreateTextureFromSurface(textureSurface)
+	if err != nil {
+		internal.GetInternalLogger().Error("Failed to create image texture", "path", path, "error", err)
+		return
+	}
+	s.texture = texture
+}
+
+// scale returns the current zoom as screen pixels per image pixel.
+func (s *imageViewerState) scale() float64 {
+	switch s.zoom {
+	case ImageViewerZoomFill:
+		return internal.ImageFitScale(s.imageW, s.imageH, s.window.GetWidth(), s.window.GetHeight(), s.quarterTurns, true)
+	case ImageViewerZoomActual:
+		return 1
+	default:
+		return internal.ImageFitScale(s.imageW, s.imageH, s.window.GetWidth(), s.window.GetHeight(), s.quarterTurns, false)
+	}
+}
+
+// displaySize returns the size of the image on screen, after rotation.
+func (s *imageViewerState) displaySize() (int32, int32) {
+	scale := s.scale()
+	w, h := int32(float64(s.imageW)*scale), int32(float64(s.imageH)*scale)
+	if s.quarterTurns%2 != 0 {
+		return h, w
+	}
+	return w, h
+}
+
+func (s *imageViewerState) canPanX() bool {
+	w, _ := s.displaySize()
+	return w > s.window.GetWidth()
+}
+
+func (s *imageViewerState) clampPan() {
+	w, h := s.displaySize()
+	s.panX = internal.ClampImagePan(s.panX, w, s.window.GetWidth())
+	s.panY = internal.ClampImagePan(s.panY, h, s.window.GetHeight())
+}
+
+func (s *imageViewerState) showImage(index int) {
+	count := len(s.imagePaths)
+	index = (index%count + count) % count
+	if index == s.index {
+		return
+	}
+	s.index = index
+	s.loadImage()
+}
+
+func (s *imageViewerState) handleEvents() {
+	processor := internal.GetInputProcessor()
+
+	if event := sdl.WaitEventTimeout(16); event != nil {
+		switch e := event.(type) {
+		case *sdl.QuitEvent:
+			s.done = true
+		case *sdl.ControllerAxisEvent:
+			// The right stick pans with analog speed; other axes map to buttons as usual
+			switch e.Axis {
+			case sdl.CONTROLLER_AXIS_RIGHTX:
+				s.stickX = e.Value
+				return
+			case sdl.CONTROLLER_AXIS_RIGHTY:
+				s.stickY = e.Value
+				return
+			}
+			s.handleInput(processor.ProcessSDLEvent(event))
+		case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent:
+			s.handleInput(processor.ProcessSDLEvent(event.(sdl.Event)))
+		}
+	}
+}
+
+func (s *imageViewerState) handleInput(inputEvent *internal.Event) {
+	if inputEvent == nil {
+		return
+	}
+
+	if !inputEvent.Pressed {
+		s.setHeld(inputEvent.Button, false)
+		return
+	}
+
+	if time.Since(s.lastInputTime) < s.inputDelay {
+		return
+	}
+	s.lastInputTime = time.Now()
+
+	switch inputEvent.Button {
+	case constants.VirtualButtonB:
+		s.done = true
+	case constants.VirtualButtonA:
+		s.zoom = (s.zoom + 1) % 3
+		s.clampPan()
+	case constants.VirtualButtonX:
+		s.quarterTurns = (s.quarterTurns + 1) % 4
+		s.clampPan()
+	case constants.VirtualButtonY:
+		s.showInfo = !s.showInfo
+	case constants.VirtualButtonL1:
+		s.showImage(s.index - 1)
+	case constants.VirtualButtonR1:
+		s.showImage(s.index + 1)
+	case constants.VirtualButtonLeft, constants.VirtualButtonRight:
+		if s.canPanX() {
+			s.setHeld(inputEvent.Button, true)
+		} else if inputEvent.Button == constants.VirtualButtonLeft {
+			s.showImage(s.index - 1)
+		} else {
+			s.showImage(s.index + 1)
+		}
+	case constants.VirtualButtonUp, constants.VirtualButtonDown:
+		s.setHeld(inputEvent.Button, true)
+	}
+}
+
+func (s *imageViewerState) setHeld(button constants.VirtualButton, held bool) {
+	switch button {
+	case constants.VirtualButtonUp:
+		s.held.up = held
+	case constants.VirtualButtonDown:
+		s.held.down = held
+	case constants.VirtualButtonLeft:
+		s.held.left = held
+	case constants.VirtualButtonRight:
+		s.held.right = held
+	}
+}
+
+// update pans by the held directions and stick deflection, scaled by the frame time.
+func (s *imageViewerState) update() {
+	now := time.Now()
+	elapsed := now.Sub(s.lastFrame).Seconds()
+	s.lastFrame = now
+
+	var dx, dy float64
+	if s.held.left {
+		dx -= imageViewerPanSpeed
+	}
+	if s.held.right {
+		dx += imageViewerPanSpeed
+	}
+	if s.held.up {
+		dy -= imageViewerPanSpeed
+	}
+	if s.held.down {
+		dy += imageViewerPanSpeed
+	}
+	if internal.Abs(int(s.stickX)) > imageViewerStickDeadzone {
+		dx += float64(s.stickX) / 32767 * imageViewerStickSpeed
+	}
+	if internal.Abs(int(s.stickY)) > imageViewerStickDeadzone {
+		dy += float64(s.stickY) / 32767 * imageViewerStickSpeed
+	}
+
+	// Moving the view right slides the image left
+	s.panX -= dx * elapsed
+	s.panY -= dy * elapsed
+	s.clampPan()
+}
+
+func (s *imageViewerState) render() {
+	bg := s.options.BackgroundColor
+	s.renderer.SetDrawColor(bg.R, bg.G, bg.B, bg.A)
+	s.renderer.Clear()
+
+	windowW, windowH := s.window.GetWidth(), s.window.GetHeight()
+
+	if s.texture != nil {
+		// CopyEx rotates about the center of the unrotated destination rect
+		scale := s.scale()
+		w, h := int32(float64(s.imageW)*scale), int32(float64(s.imageH)*scale)
+		dst := sdl.Rect{
+			X: windowW/2 + int32(s.panX) - w/2,
+			Y: windowH/2 + int32(s.panY) - h/2,
+			W: w,
+			H: h,
+		}
+		s.renderer.CopyEx(s.texture, nil, &dst, float64(s.quarterTurns*90), nil, sdl.FLIP_NONE)
+	} else {
+		internal.RenderMultilineTextWithCache(
+			s.renderer,
+			"Unable to load image",
+			internal.Fonts.SmallFont,
+			windowW,
+			0,
+			windowH/2,
+			sdl.Color{R: 200, G: 200, B: 200, A: 255},
+			constants.TextAlignCenter,
+			s.textureCache)
+	}
+
+	if s.showInfo {
+		s.renderInfo()
+	}
+
+	s.window.Present()
+}
+
+func (s *imageViewerState) renderInfo() {
+	margins := internal.UniformPadding(20)
+	font := internal.Fonts.SmallFont
+	windowW := s.window.GetWidth()
+
+	info := filepath.Base(s.imagePaths[s.index])
+	if s.texture != nil {
+		info += fmt.Sprintf("\n%d × %d  ·  %d%%", s.imageW, s.imageH, int(s.scale()*100+0.5))
+	}
+	if len(s.imagePaths) > 1 {
+		info += fmt.Sprintf("  ·  %d / %d", s.index+1, len(s.imagePaths))
+	}
+
+	textWidth := windowW - margins.Left - margins.Right
+	height := calculateMultilineTextHeight(info, font, textWidth)
+
+	s.renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+	s.renderer.SetDrawColor(0, 0, 0, 180)
+	s.renderer.FillRect(&sdl.Rect{X: 0, Y: 0, W: windowW, H: height})
+	s.renderer.SetDrawBlendMode(sdl.BLENDMODE_NONE)
+
+	internal.RenderMultilineTextWithCache(
+		s.renderer,
+		info,
+		font,
+		textWidth,
+		margins.Left,
+		10,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		constants.TextAlignLeft,
+		s.textureCache)
+
+	renderFooter(s.renderer, font, s.options.FooterHelpItems, margins.Bottom, false, false)
+}
@@ -40,17 +40,19 @@ const (
 
 // Options configures the gabagool UI framework initialization.
 type Options struct {
-	WindowTitle          string                 // Window title displayed in windowed mode
-	ShowBackground       bool                   // Whether to render the theme background
-	WindowOptions        internal.WindowOptions // SDL window flags (borderless, resizable, etc.)
-	PrimaryThemeColorHex uint32                 // Custom accent color (ignored on NextUI which uses system theme)
-	IsNextUI             bool                   // Enable NextUI CFW theming and power button handling
-	ControllerConfigFile string                 // Path to custom controller mapping file
-	LogPath              string                 // Full path for log file including filename (creates parent directories)
-	LogFilename          string                 // Deprecated: Use LogPath instead. Log filename within "logs" directory.
-	FlipFaceButtons      bool                   // Use direct face button mapping (A=A, B=B) instead of Nintendo-style swap
-	DisplayOrientation   DisplayOrientation     // Clockwise rotation of the display (0, 90, 180, 270 degrees)
-	DisabledInputSources DisabledInputSources   // Input event types to ignore (keyboard, controller, joystick)
+	WindowTitle            string                 // Window title displayed in windowed mode
+	ShowBackground         bool                   // Whether to render the theme background
+	WindowOptions          internal.WindowOptions // SDL window flags (borderless, resizable, etc.)
+	PrimaryThemeColorHex   uint32                 // Custom accent color (ignored on NextUI which uses system theme)
+	IsNextUI               bool                   // Enable NextUI CFW theming and power button handling
+	ControllerConfigFile   string                 // Path to custom controller mapping file
+	LogPath                string                 // Full path for log file including filename (creates parent directories)
+	LogFilename            string                 // Deprecated: Use LogPath instead. Log filename within "logs" directory.
+	FlipFaceButtons        bool                   // Use direct face button mapping (A=A, B=B) instead of Nintendo-style swap
+	DisplayOrientation     DisplayOrientation     // Clockwise rotation of the display (0, 90, 180, 270 degrees)
+	DisabledInputSources   DisabledInputSources   // Input event types to ignore (keyboard, controller, joystick)
+	KeyboardHistoryPath    string                 // File keyboard input history is stored in (default: memory only)
+	TextReaderPositionPath string                 // File text reader positions are stored in (default: memory only)
 }
 
 // Init initializes the SDL subsystems, theming, and input handling.
@@ -69,6 +71,14 @@ func Init(options Options) {
 		internal.SetInternalLogLevel(slog.LevelError)
 	}
 
+	if options.KeyboardHistoryPath != "" {
+		SetKeyboardHistoryPath(options.KeyboardHistoryPath)
+	}
+
+	if options.TextReaderPositionPath != "" {
+		SetTextReaderPositionPath(options.TextReaderPositionPath)
+	}
+
 	// Set face button flip preference before input mapping is loaded
 	internal.SetFlipFaceButtons(options.FlipFaceButtons)
 
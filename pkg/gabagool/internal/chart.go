@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// NiceChartScale widens lo..hi to round axis bounds and picks a round tick step, with
+// at most about maxTicks ticks. An empty range is widened so it can still be drawn.
+func NiceChartScale(lo, hi float64, maxTicks int) (scaleMin, scaleMax, step float64) {
+	if maxTicks < 2 {
+		maxTicks = 2
+	}
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	if hi == lo {
+		if lo == 0 {
+			hi = 1
+		} else {
+			spread := math.Abs(lo) / 2
+			lo, hi = lo-spread, hi+spread
+		}
+	}
+
+	span := niceChartNumber(hi-lo, false)
+	step = niceChartNumber(span/float64(maxTicks-1), true)
+
+	// The epsilon keeps float error from pushing a bound out by a whole step
+	scaleMin = math.Floor(lo/step+1e-9) * step
+	scaleMax = math.Ceil(hi/step-1e-9) * step
+	return scaleMin, scaleMax, step
+}
+
+// niceChartNumber returns a number of 1, 2, 5 or 10 times a power of ten close to x,
+// rounded to the nearest when round is set and up otherwise.
+func niceChartNumber(x float64, round bool) float64 {
+	exponent := math.Floor(math.Log10(x))
+	power := math.Pow(10, exponent)
+	fraction := x / power
+
+	var nice float64
+	if round {
+		switch {
+		case fraction < 1.5:
+			nice = 1
+		case fraction < 3:
+			nice = 2
+		case fraction < 7:
+			nice = 5
+		default:
+			nice = 10
+		}
+	} else {
+		switch {
+		case fraction <= 1:
+			nice = 1
+		case fraction <= 2:
+			nice = 2
+		case fraction <= 5:
+			nice = 5
+		default:
+			nice = 10
+		}
+	}
+	return nice * power
+}
+
+// FormatChartValue formats a value for an axis or value label: large values are
+// shortened to k, M and B, and the rest keep at most two decimals.
+func FormatChartValue(value float64) string {
+	abs := math.Abs(value)
+	switch {
+	case abs >= 1e9:
+		return formatChartDecimal(value/1e9, 1) + "B"
+	case abs >= 1e6:
+		return formatChartDecimal(value/1e6, 1) + "M"
+	case abs >= 1e4:
+		return formatChartDecimal(value/1e3, 1) + "k"
+	}
+	return formatChartDecimal(value, 2)
+}
+
+func formatChartDecimal(value float64, decimals int) string {
+	scale := math.Pow(10, float64(decimals))
+	rounded := math.Round(value*scale) / scale
+	if rounded == 0 {
+		rounded = 0 // no "-0"
+	}
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
+
+// ChartLabelStride returns how many categories apart labels must be so labels up to
+// labelWidth wide don't overlap when each category is slotWidth wide.
+func ChartLabelStride(labelWidth, slotWidth, gap int32) int {
+	if slotWidth <= 0 {
+		return 1
+	}
+	stride := int((labelWidth + gap + slotWidth - 1) / slotWidth)
+	return max(stride, 1)
+}
+
+// ChartSeriesColor returns the color of the series at index: the theme accent for the
+// first, then its hue turned by the golden angle so neighbouring series stand apart.
+func ChartSeriesColor(accent sdl.Color, index int) sdl.Color {
+	if index == 0 {
+		return sdl.Color{R: accent.R, G: accent.G, B: accent.B, A: 255}
+	}
+
+	h, s, v := RGBToHSV(accent)
+	// Grey or dark accents have no hue to turn
+	s = math.Max(s, 0.5)
+	v = math.Max(v, 0.7)
+	return HSVToRGB(h+137.5*float64(index), s, v, 255)
+}
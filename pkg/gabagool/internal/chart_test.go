@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func TestNiceChartScale(t *testing.T) {
+	tests := []struct {
+		name           string
+		lo, hi         float64
+		maxTicks       int
+		min, max, step float64
+	}{
+		{name: "from zero", lo: 0, hi: 87, maxTicks: 5, min: 0, max: 100, step: 20},
+		{name: "exact bounds", lo: 0, hi: 100, maxTicks: 6, min: 0, max: 100, step: 20},
+		{name: "small values", lo: 0, hi: 0.3, maxTicks: 4, min: 0, max: 0.4, step: 0.2},
+		{name: "negative", lo: -12, hi: 38, maxTicks: 6, min: -20, max: 40, step: 10},
+		{name: "offset range", lo: 1012, hi: 1048, maxTicks: 5, min: 1010, max: 1050, step: 10},
+		{name: "all zero", lo: 0, hi: 0, maxTicks: 5, min: 0, max: 1, step: 0.2},
+		{name: "flat", lo: 5, hi: 5, maxTicks: 5, min: 2, max: 8, step: 1},
+		{name: "reversed", lo: 87, hi: 0, maxTicks: 5, min: 0, max: 100, step: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, step := NiceChartScale(tt.lo, tt.hi, tt.maxTicks)
+			if !closeTo(lo, tt.min) || !closeTo(hi, tt.max) || !closeTo(step, tt.step) {
+				t.Errorf("NiceChartScale(%v, %v, %d) = %v, %v, %v, want %v, %v, %v",
+					tt.lo, tt.hi, tt.maxTicks, lo, hi, step, tt.min, tt.max, tt.step)
+			}
+		})
+	}
+}
+
+func closeTo(a, b float64) bool {
+	d := a - b
+	return d < 1e-9 && d > -1e-9
+}
+
+func TestFormatChartValue(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{0, "0"},
+		{42, "42"},
+		{0.30000000000000004, "0.3"},
+		{2.345, "2.35"},
+		{-0.001, "0"},
+		{9999, "9999"},
+		{12500, "12.5k"},
+		{-48000, "-48k"},
+		{3400000, "3.4M"},
+		{7.25e9, "7.3B"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatChartValue(tt.value); got != tt.want {
+			t.Errorf("FormatChartValue(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestChartLabelStride(t *testing.T) {
+	tests := []struct {
+		labelWidth, slotWidth, gap int32
+		want                       int
+	}{
+		{labelWidth: 20, slotWidth: 40, gap: 8, want: 1},
+		{labelWidth: 32, slotWidth: 40, gap: 8, want: 1},
+		{labelWidth: 40, slotWidth: 40, gap: 8, want: 2},
+		{labelWidth: 60, slotWidth: 10, gap: 8, want: 7},
+		{labelWidth: 60, slotWidth: 0, gap: 8, want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := ChartLabelStride(tt.labelWidth, tt.slotWidth, tt.gap); got != tt.want {
+			t.Errorf("ChartLabelStride(%d, %d, %d) = %d, want %d", tt.labelWidth, tt.slotWidth, tt.gap, got, tt.want)
+		}
+	}
+}
+
+func TestChartSeriesColor(t *testing.T) {
+	accent := sdl.Color{R: 0, G: 128, B: 128, A: 200}
+
+	if got := ChartSeriesColor(accent, 0); got != (sdl.Color{R: 0, G: 128, B: 128, A: 255}) {
+		t.Errorf("first series = %v, want the opaque accent", got)
+	}
+
+	seen := map[sdl.Color]bool{}
+	for i := 0; i < 5; i++ {
+		color := ChartSeriesColor(accent, i)
+		if seen[color] {
+			t.Errorf("series %d repeats color %v", i, color)
+		}
+		seen[color] = true
+	}
+
+	grey := sdl.Color{R: 90, G: 90, B: 90, A: 255}
+	if got := ChartSeriesColor(grey, 1); got.R == got.G && got.G == got.B {
+		t.Errorf("series from a grey accent = %v, want a color", got)
+	}
+}
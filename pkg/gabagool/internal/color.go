@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// RGBToHSV converts a color to hue (0-360), saturation (0-1) and value (0-1).
+// Alpha is ignored.
+func RGBToHSV(c sdl.Color) (h, s, v float64) {
+	r := float64(c.R) / 255
+	g := float64(c.G) / 255
+	b := float64(c.B) / 255
+
+	maxC := math.Max(r, math.Max(g, b))
+	minC := math.Min(r, math.Min(g, b))
+	delta := maxC - minC
+
+	v = maxC
+	if maxC > 0 {
+		s = delta / maxC
+	}
+
+	if delta == 0 {
+		return 0, s, v
+	}
+
+	switch maxC {
+	case r:
+		h = 60 * math.Mod((g-b)/delta, 6)
+	case g:
+		h = 60 * ((b-r)/delta + 2)
+	default:
+		h = 60 * ((r-g)/delta + 4)
+	}
+
+	if h < 0 {
+		h += 360
+	}
+
+	return h, s, v
+}
+
+// HSVToRGB converts hue (0-360), saturation (0-1) and value (0-1) to a color with the given alpha.
+func HSVToRGB(h, s, v float64, a uint8) sdl.Color {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	s = math.Max(0, math.Min(1, s))
+	v = math.Max(0, math.Min(1, v))
+
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return sdl.Color{
+		R: uint8(math.Round((r + m) * 255)),
+		G: uint8(math.Round((g + m) * 255)),
+		B: uint8(math.Round((b + m) * 255)),
+		A: a,
+	}
+}
+
+// ParseHexColor parses "#RGB", "#RRGGBB" or "#RRGGBBAA" (the leading '#' is optional).
+// Colors without an alpha component are fully opaque.
+func ParseHexColor(s string) (sdl.Color, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "FF"
+	}
+	if len(hex) != 8 {
+		return sdl.Color{}, fmt.Errorf("invalid hex color %q", s)
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return sdl.Color{}, fmt.Errorf("invalid hex color %q", s)
+	}
+
+	return sdl.Color{
+		R: uint8(value >> 24),
+		G: uint8(value >> 16),
+		B: uint8(value >> 8),
+		A: uint8(value),
+	}, nil
+}
+
+// FormatHexColor formats a color as "#RRGGBB", or "#RRGGBBAA" when withAlpha is set.
+func FormatHexColor(c sdl.Color, withAlpha bool) string {
+	if withAlpha {
+		return fmt.Sprintf("#%02X%02X%02X%02X", c.R, c.G, c.B, c.A)
+	}
+	return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+}
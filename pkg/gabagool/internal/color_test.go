@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func TestHSVRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		color   sdl.Color
+		h, s, v float64
+	}{
+		{name: "red", color: sdl.Color{R: 255, A: 255}, h: 0, s: 1, v: 1},
+		{name: "green", color: sdl.Color{G: 255, A: 255}, h: 120, s: 1, v: 1},
+		{name: "blue", color: sdl.Color{B: 255, A: 255}, h: 240, s: 1, v: 1},
+		{name: "white", color: sdl.Color{R: 255, G: 255, B: 255, A: 255}, h: 0, s: 0, v: 1},
+		{name: "black", color: sdl.Color{A: 255}, h: 0, s: 0, v: 0},
+		{name: "teal", color: sdl.Color{G: 128, B: 128, A: 255}, h: 180, s: 1, v: 128.0 / 255},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, s, v := RGBToHSV(tt.color)
+			if math.Abs(h-tt.h) > 0.01 || math.Abs(s-tt.s) > 0.01 || math.Abs(v-tt.v) > 0.01 {
+				t.Errorf("RGBToHSV() = (%.2f, %.2f, %.2f), want (%.2f, %.2f, %.2f)", h, s, v, tt.h, tt.s, tt.v)
+			}
+
+			if got := HSVToRGB(h, s, v, tt.color.A); got != tt.color {
+				t.Errorf("HSVToRGB() = %v, want %v", got, tt.color)
+			}
+		})
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    sdl.Color
+		wantErr bool
+	}{
+		{in: "#FF8000", want: sdl.Color{R: 255, G: 128, B: 0, A: 255}},
+		{in: "ff8000", want: sdl.Color{R: 255, G: 128, B: 0, A: 255}},
+		{in: "#F80", want: sdl.Color{R: 255, G: 136, B: 0, A: 255}},
+		{in: "#00808080", want: sdl.Color{R: 0, G: 128, B: 128, A: 128}},
+		{in: "#12345", wantErr: true},
+		{in: "#GGGGGG", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseHexColor(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHexColor(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseHexColor(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatHexColor(t *testing.T) {
+	c := sdl.Color{R: 0, G: 128, B: 255, A: 64}
+	if got := FormatHexColor(c, false); got != "#0080FF" {
+		t.Errorf("FormatHexColor(false) = %q, want #0080FF", got)
+	}
+	if got := FormatHexColor(c, true); got != "#0080FF40" {
+		t.Errorf("FormatHexColor(true) = %q, want #0080FF40", got)
+	}
+}
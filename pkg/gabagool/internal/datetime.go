@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// DateOrder describes the order in which day, month and year are written.
+type DateOrder int
+
+const (
+	DateOrderDMY DateOrder = iota
+	DateOrderMDY
+	DateOrderYMD
+)
+
+// DateFormat describes how a locale writes a numeric date.
+type DateFormat struct {
+	Order     DateOrder
+	Separator string
+}
+
+// DateFormatForLanguage returns the numeric date format conventionally used for a language.
+// Unknown languages fall back to day-month-year with a slash separator.
+func DateFormatForLanguage(tag language.Tag) DateFormat {
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+
+	switch base.String() {
+	case "en":
+		switch region.String() {
+		case "US", "ZZ", "PH":
+			return DateFormat{Order: DateOrderMDY, Separator: "/"}
+		case "CA":
+			return DateFormat{Order: DateOrderYMD, Separator: "-"}
+		}
+		return DateFormat{Order: DateOrderDMY, Separator: "/"}
+	case "ja", "zh":
+		return DateFormat{Order: DateOrderYMD, Separator: "/"}
+	case "ko", "hu":
+		return DateFormat{Order: DateOrderYMD, Separator: "."}
+	case "sv", "lt":
+		return DateFormat{Order: DateOrderYMD, Separator: "-"}
+	case "de", "ru", "uk", "pl", "cs", "sk", "fi", "nb", "no", "da", "tr", "ro", "hr", "sl", "sr", "bg":
+		return DateFormat{Order: DateOrderDMY, Separator: "."}
+	case "nl":
+		return DateFormat{Order: DateOrderDMY, Separator: "-"}
+	}
+
+	return DateFormat{Order: DateOrderDMY, Separator: "/"}
+}
+
+// Format writes t using the date format, e.g. "31/12/2025" or "2025-12-31".
+func (f DateFormat) Format(t time.Time) string {
+	day := fmt.Sprintf("%02d", t.Day())
+	month := fmt.Sprintf("%02d", int(t.Month()))
+	year := fmt.Sprintf("%04d", t.Year())
+
+	switch f.Order {
+	case DateOrderMDY:
+		return month + f.Separator + day + f.Separator + year
+	case DateOrderYMD:
+		return year + f.Separator + month + f.Separator + day
+	default:
+		return day + f.Separator + month + f.Separator + year
+	}
+}
+
+// Uses12HourClock reports whether a language conventionally uses a 12-hour clock.
+func Uses12HourClock(tag language.Tag) bool {
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+
+	switch base.String() {
+	case "en":
+		switch region.String() {
+		case "GB", "IE", "ZA":
+			return false
+		}
+		return true
+	case "hi", "ar", "bn", "ur", "fil":
+		return true
+	}
+
+	return false
+}
+
+// DaysInMonth returns the number of days in the given month, accounting for leap years.
+func DaysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// WrapInt wraps value into the inclusive range [min, max].
+func WrapInt(value, min, max int) int {
+	span := max - min + 1
+	if span <= 0 {
+		return min
+	}
+	offset := (value - min) % span
+	if offset < 0 {
+		offset += span
+	}
+	return min + offset
+}
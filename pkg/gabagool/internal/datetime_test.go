@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func TestDateFormatForLanguage(t *testing.T) {
+	date := time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		tag  language.Tag
+		want string
+	}{
+		{name: "plain english is US style", tag: language.English, want: "03/07/2025"},
+		{name: "american english", tag: language.AmericanEnglish, want: "03/07/2025"},
+		{name: "british english", tag: language.BritishEnglish, want: "07/03/2025"},
+		{name: "german uses dots", tag: language.German, want: "07.03.2025"},
+		{name: "japanese is year first", tag: language.Japanese, want: "2025/03/07"},
+		{name: "swedish is ISO-like", tag: language.Swedish, want: "2025-03-07"},
+		{name: "unknown falls back to DMY", tag: language.Spanish, want: "07/03/2025"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DateFormatForLanguage(tt.tag).Format(date)
+			if got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUses12HourClock(t *testing.T) {
+	tests := []struct {
+		tag  language.Tag
+		want bool
+	}{
+		{tag: language.English, want: true},
+		{tag: language.BritishEnglish, want: false},
+		{tag: language.French, want: false},
+		{tag: language.Hindi, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag.String(), func(t *testing.T) {
+			if got := Uses12HourClock(tt.tag); got != tt.want {
+				t.Errorf("Uses12HourClock(%s) = %v, want %v", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaysInMonth(t *testing.T) {
+	tests := []struct {
+		year  int
+		month time.Month
+		want  int
+	}{
+		{year: 2025, month: time.January, want: 31},
+		{year: 2025, month: time.February, want: 28},
+		{year: 2024, month: time.February, want: 29},
+		{year: 1900, month: time.February, want: 28},
+		{year: 2000, month: time.February, want: 29},
+		{year: 2025, month: time.April, want: 30},
+		{year: 2025, month: time.December, want: 31},
+	}
+
+	for _, tt := range tests {
+		if got := DaysInMonth(tt.year, tt.month); got != tt.want {
+			t.Errorf("DaysInMonth(%d, %s) = %d, want %d", tt.year, tt.month, got, tt.want)
+		}
+	}
+}
+
+func TestWrapInt(t *testing.T) {
+	tests := []struct {
+		value, min, max int
+		want            int
+	}{
+		{value: 5, min: 0, max: 59, want: 5},
+		{value: 60, min: 0, max: 59, want: 0},
+		{value: -1, min: 0, max: 59, want: 59},
+		{value: 13, min: 1, max: 12, want: 1},
+		{value: 0, min: 1, max: 12, want: 12},
+		{value: -25, min: 1, max: 12, want: 11},
+	}
+
+	for _, tt := range tests {
+		if got := WrapInt(tt.value, tt.min, tt.max); got != tt.want {
+			t.Errorf("WrapInt(%d, %d, %d) = %d, want %d", tt.value, tt.min, tt.max, got, tt.want)
+		}
+	}
+}
@@ -28,6 +28,15 @@ func wrapTextToLines(text string, font *ttf.Font, maxWidth int32, color sdl.Colo
 	paragraphs := strings.Split(normalized, "\n")
 	var lines []string
 
+	measure := func(line []rune) int32 {
+		surface, err := font.RenderUTF8Blended(string(line), color)
+		if err != nil {
+			return 0
+		}
+		defer surface.Free()
+		return surface.W
+	}
+
 	for _, paragraph := range paragraphs {
 
 		if paragraph == "" {
@@ -40,29 +49,9 @@ func wrapTextToLines(text string, font *ttf.Font, maxWidth int32, color sdl.Colo
 			continue
 		}
 
-		currentLine := words[0]
-
-		for _, word := range words[1:] {
-
-			testLine := currentLine + " " + word
-			testSurface, err := font.RenderUTF8Blended(testLine, color)
-			if err != nil {
-				continue
-			}
-
-			fits := testSurface.W <= maxWidth
-			testSurface.Free()
-
-			if fits {
-				currentLine = testLine
-			} else {
-				lines = append(lines, currentLine)
-				currentLine = word
-			}
-		}
-
-		if currentLine != "" {
-			lines = append(lines, currentLine)
+		runes := []rune(strings.Join(words, " "))
+		for _, span := range WrapSpans(runes, maxWidth, measure, false) {
+			lines = append(lines, strings.TrimRight(string(runes[span.Start:span.End]), " "))
 		}
 	}
 
@@ -358,6 +347,11 @@ func DrawFilledCircle(renderer *sdl.Renderer, centerX, centerY, radius int32, co
 	})
 }
 
+// DrawFilledTriangle draws a solid triangle.
+func DrawFilledTriangle(renderer *sdl.Renderer, x1, y1, x2, y2, x3, y3 int32, color sdl.Color) {
+	gfx.FilledTrigonColor(renderer, x1, y1, x2, y2, x3, y3, color)
+}
+
 func drawFilledCirclePrimitives(renderer *sdl.Renderer, centerX, centerY, radius int32, color sdl.Color) {
 	gfx.FilledCircleColor(renderer, centerX, centerY, radius, color)
 
@@ -0,0 +1,30 @@
+package internal
+
+// ImageFitScale returns the scale that shows a w×h image whole inside a viewW×viewH
+// view, or with fill set, the scale that covers the view and crops the overflow.
+// Images turned by an odd number of quarter turns are measured on their side.
+func ImageFitScale(w, h, viewW, viewH int32, quarterTurns int, fill bool) float64 {
+	if quarterTurns%2 != 0 {
+		w, h = h, w
+	}
+	if w <= 0 || h <= 0 {
+		return 1
+	}
+
+	scaleX := float64(viewW) / float64(w)
+	scaleY := float64(viewH) / float64(h)
+	if fill {
+		return max(scaleX, scaleY)
+	}
+	return min(scaleX, scaleY)
+}
+
+// ClampImagePan limits the offset of an image's center from the view's center so its
+// edges never pull inside the view. Images no larger than the view stay centered.
+func ClampImagePan(pan float64, size, view int32) float64 {
+	limit := float64(size-view) / 2
+	if limit <= 0 {
+		return 0
+	}
+	return max(-limit, min(pan, limit))
+}
@@ -0,0 +1,49 @@
+package internal
+
+import "testing"
+
+func TestImageFitScale(t *testing.T) {
+	tests := []struct {
+		name         string
+		w, h         int32
+		quarterTurns int
+		fill         bool
+		want         float64
+	}{
+		{name: "fit wide", w: 1280, h: 480, want: 0.5},
+		{name: "fill wide", w: 1280, h: 480, fill: true, want: 1},
+		{name: "fit small grows", w: 320, h: 240, want: 2},
+		{name: "fit rotated", w: 480, h: 1280, quarterTurns: 1, want: 0.5},
+		{name: "half turn keeps orientation", w: 1280, h: 480, quarterTurns: 2, want: 0.5},
+		{name: "fill rotated", w: 240, h: 640, quarterTurns: 3, fill: true, want: 2},
+		{name: "empty image", w: 0, h: 0, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ImageFitScale(tt.w, tt.h, 640, 480, tt.quarterTurns, tt.fill); got != tt.want {
+				t.Errorf("ImageFitScale = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClampImagePan(t *testing.T) {
+	tests := []struct {
+		pan  float64
+		size int32
+		want float64
+	}{
+		{pan: 50, size: 400, want: 0},
+		{pan: 50, size: 640, want: 0},
+		{pan: 50, size: 1000, want: 50},
+		{pan: 500, size: 1000, want: 180},
+		{pan: -500, size: 1000, want: -180},
+	}
+
+	for _, tt := range tests {
+		if got := ClampImagePan(tt.pan, tt.size, 640); got != tt.want {
+			t.Errorf("ClampImagePan(%v, %d) = %v, want %v", tt.pan, tt.size, got, tt.want)
+		}
+	}
+}
@@ -70,6 +70,12 @@ func (ip *Processor) ReloadMapping() {
 	ip.mapping = GetInputMapping()
 }
 
+// IsKeyMapped reports whether a keyboard key is bound to a virtual button.
+func (ip *Processor) IsKeyMapped(key sdl.Keycode) bool {
+	_, ok := ip.mapping.KeyboardMap[key]
+	return ok
+}
+
 // SetDisabledInputSources replaces the processor's disabled-sources config.
 // Changes take effect immediately for all subsequent events.
 func (ip *Processor) SetDisabledInputSources(s DisabledInputSources) {
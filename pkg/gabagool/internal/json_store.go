@@ -0,0 +1,100 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// JSONStore is a small keyed store saved as a JSON object, such as keyboard history or
+// reading positions. Until a path is set it is kept only in memory. It is safe for
+// concurrent use.
+type JSONStore[V any] struct {
+	mu     sync.Mutex
+	path   string // empty = kept in memory only
+	memory map[string]V
+}
+
+// SetPath sets the file the store is saved in. Parent directories are created when
+// it is first saved.
+func (s *JSONStore[V]) SetPath(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.path = path
+}
+
+// Get returns the value stored under key. A store that can't be read has no values.
+func (s *JSONStore[V]) Get(key string) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	store, _ := s.load()
+	value, ok := store[key]
+	return value, ok
+}
+
+// Update loads the store, lets fn change it, and saves it if fn reports a change.
+func (s *JSONStore[V]) Update(fn func(store map[string]V) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	store, err := s.load()
+	if err != nil {
+		return err
+	}
+	if !fn(store) {
+		return nil
+	}
+	return s.save(store)
+}
+
+// load reads the store file. A missing file is an empty store.
+func (s *JSONStore[V]) load() (map[string]V, error) {
+	store := make(map[string]V)
+	if s.path == "" {
+		maps.Copy(store, s.memory)
+		return store, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return store, err
+	}
+
+	if err := json.Unmarshal(data, &store); err != nil {
+		return make(map[string]V), err
+	}
+	return store, nil
+}
+
+// save writes the store file through a temporary file so an interrupted write never
+// leaves it truncated.
+func (s *JSONStore[V]) save(store map[string]V) error {
+	if s.path == "" {
+		s.memory = store
+		return nil
+	}
+
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func addToStore(t *testing.T, s *JSONStore[int], key string, value int) {
+	t.Helper()
+	err := s.Update(func(store map[string]int) bool {
+		store[key] = value
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJSONStoreMemory(t *testing.T) {
+	var s JSONStore[int]
+	if _, ok := s.Get("a"); ok {
+		t.Fatal("empty store has a value")
+	}
+
+	addToStore(t, &s, "a", 1)
+	if got, ok := s.Get("a"); !ok || got != 1 {
+		t.Errorf("Get(a) = %d, %v, want 1, true", got, ok)
+	}
+
+	// A change that isn't reported isn't saved
+	s.Update(func(store map[string]int) bool {
+		store["b"] = 2
+		return false
+	})
+	if _, ok := s.Get("b"); ok {
+		t.Error("unreported change was saved")
+	}
+}
+
+func TestJSONStoreFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "store.json")
+
+	var s JSONStore[int]
+	s.SetPath(path)
+	if _, ok := s.Get("a"); ok {
+		t.Fatal("missing file has a value")
+	}
+
+	addToStore(t, &s, "a", 1)
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	var reopened JSONStore[int]
+	reopened.SetPath(path)
+	if got, ok := reopened.Get("a"); !ok || got != 1 {
+		t.Errorf("Get(a) after reopening = %d, %v, want 1, true", got, ok)
+	}
+}
+
+func TestJSONStoreCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var s JSONStore[int]
+	s.SetPath(path)
+	if _, ok := s.Get("a"); ok {
+		t.Error("corrupt file has a value")
+	}
+
+	// The file isn't overwritten while it can't be read
+	if err := s.Update(func(store map[string]int) bool { return true }); err == nil {
+		t.Error("Update on a corrupt file succeeded")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "{not json" {
+		t.Errorf("corrupt file was overwritten with %q", data)
+	}
+}
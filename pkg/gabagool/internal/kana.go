@@ -0,0 +1,171 @@
+package internal
+
+import "strings"
+
+var romajiTable = map[string]string{
+	"a": "あ", "i": "い", "u": "う", "e": "え", "o": "お",
+	"ka": "か", "ki": "き", "ku": "く", "ke": "け", "ko": "こ",
+	"kya": "きゃ", "kyu": "きゅ", "kyo": "きょ",
+	"sa": "さ", "shi": "し", "si": "し", "su": "す", "se": "せ", "so": "そ",
+	"sha": "しゃ", "shu": "しゅ", "she": "しぇ", "sho": "しょ",
+	"sya": "しゃ", "syu": "しゅ", "syo": "しょ",
+	"ta": "た", "chi": "ち", "ti": "ち", "tsu": "つ", "tu": "つ", "te": "て", "to": "と",
+	"cha": "ちゃ", "chu": "ちゅ", "che": "ちぇ", "cho": "ちょ",
+	"tya": "ちゃ", "tyu": "ちゅ", "tyo": "ちょ",
+	"na": "な", "ni": "に", "nu": "ぬ", "ne": "ね", "no": "の",
+	"nya": "にゃ", "nyu": "にゅ", "nyo": "にょ",
+	"ha": "は", "hi": "ひ", "fu": "ふ", "hu": "ふ", "he": "へ", "ho": "ほ",
+	"hya": "ひゃ", "hyu": "ひゅ", "hyo": "ひょ",
+	"fa": "ふぁ", "fi": "ふぃ", "fe": "ふぇ", "fo": "ふぉ",
+	"ma": "ま", "mi": "み", "mu": "む", "me": "め", "mo": "も",
+	"mya": "みゃ", "myu": "みゅ", "myo": "みょ",
+	"ya": "や", "yu": "ゆ", "yo": "よ",
+	"ra": "ら", "ri": "り", "ru": "る", "re": "れ", "ro": "ろ",
+	"rya": "りゃ", "ryu": "りゅ", "ryo": "りょ",
+	"wa": "わ", "wo": "を",
+	"ga": "が", "gi": "ぎ", "gu": "ぐ", "ge": "げ", "go": "ご",
+	"gya": "ぎゃ", "gyu": "ぎゅ", "gyo": "ぎょ",
+	"za": "ざ", "ji": "じ", "zi": "じ", "zu": "ず", "ze": "ぜ", "zo": "ぞ",
+	"ja": "じゃ", "ju": "じゅ", "je": "じぇ", "jo": "じょ",
+	"jya": "じゃ", "jyu": "じゅ", "jyo": "じょ",
+	"zya": "じゃ", "zyu": "じゅ", "zyo": "じょ",
+	"da": "だ", "di": "ぢ", "du": "づ", "de": "で", "do": "ど",
+	"dya": "ぢゃ", "dyu": "ぢゅ", "dyo": "ぢょ",
+	"ba": "ば", "bi": "び", "bu": "ぶ", "be": "べ", "bo": "ぼ",
+	"bya": "びゃ", "byu": "びゅ", "byo": "びょ",
+	"pa": "ぱ", "pi": "ぴ", "pu": "ぷ", "pe": "ぺ", "po": "ぽ",
+	"pya": "ぴゃ", "pyu": "ぴゅ", "pyo": "ぴょ",
+	"va": "ゔぁ", "vi": "ゔぃ", "vu": "ゔ", "ve": "ゔぇ", "vo": "ゔぉ",
+	"thi": "てぃ", "dhi": "でぃ",
+	"xa": "ぁ", "xi": "ぃ", "xu": "ぅ", "xe": "ぇ", "xo": "ぉ",
+	"la": "ぁ", "li": "ぃ", "lu": "ぅ", "le": "ぇ", "lo": "ぉ",
+	"xya": "ゃ", "xyu": "ゅ", "xyo": "ょ",
+	"lya": "ゃ", "lyu": "ゅ", "lyo": "ょ",
+	"xtsu": "っ", "ltsu": "っ", "xtu": "っ", "ltu": "っ",
+	"nn": "ん", "n'": "ん",
+	"-": "ー",
+}
+
+// romajiPrefixes holds every proper prefix of a romajiTable key, i.e. input that may
+// still turn into a syllable once more letters are typed.
+var romajiPrefixes = func() map[string]bool {
+	prefixes := make(map[string]bool)
+	for k := range romajiTable {
+		for i := 1; i < len(k); i++ {
+			prefixes[k[:i]] = true
+		}
+	}
+	return prefixes
+}()
+
+const maxRomajiLength = 4
+
+// RomajiToKana converts romaji to hiragana, or katakana when katakana is set.
+// Trailing input that could still become a syllable ("k", "ky", "n") is returned
+// as pending instead of being converted. Characters that are not romaji are kept as-is.
+func RomajiToKana(romaji string, katakana bool) (kana, pending string) {
+	input := []rune(strings.ToLower(romaji))
+	var out strings.Builder
+
+	for i := 0; i < len(input); {
+		rest := input[i:]
+
+		if n, k := matchRomaji(rest); n > 0 {
+			out.WriteString(k)
+			i += n
+			continue
+		}
+
+		c := rest[0]
+		if len(rest) >= 2 {
+			// "n" before a consonant is ん: "kanji" -> かんじ
+			if c == 'n' && !isRomajiVowel(rest[1]) && rest[1] != 'y' && rest[1] != '\'' {
+				out.WriteString("ん")
+				i++
+				continue
+			}
+			// A doubled consonant is a small tsu: "kitte" -> きって, "matcha" -> まっちゃ
+			if isRomajiConsonant(c) && (rest[1] == c || (c == 't' && rest[1] == 'c')) {
+				out.WriteString("っ")
+				i++
+				continue
+			}
+		}
+
+		if romajiPrefixes[string(rest)] {
+			pending = string(rest)
+			break
+		}
+
+		out.WriteRune(c)
+		i++
+	}
+
+	kana = out.String()
+	if katakana {
+		kana = ToKatakana(kana)
+	}
+	return kana, pending
+}
+
+// FlushRomaji converts whatever is left at the end of a composition.
+// A lone "n" becomes ん; anything else stays as typed.
+func FlushRomaji(pending string, katakana bool) string {
+	if strings.ToLower(pending) != "n" {
+		return pending
+	}
+	if katakana {
+		return "ン"
+	}
+	return "ん"
+}
+
+// ToKatakana converts hiragana in s to katakana.
+func ToKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + 0x60
+		}
+		return r
+	}, s)
+}
+
+// ToHiragana converts katakana in s to hiragana.
+func ToHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 0x60
+		}
+		return r
+	}, s)
+}
+
+// IsRomajiInput reports whether text can be fed to the romaji composer.
+func IsRomajiInput(text string) bool {
+	if text == "" {
+		return false
+	}
+	for _, r := range text {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && r != '-' && r != '\'' {
+			return false
+		}
+	}
+	return true
+}
+
+func matchRomaji(input []rune) (int, string) {
+	for n := min(maxRomajiLength, len(input)); n > 0; n-- {
+		if k, ok := romajiTable[string(input[:n])]; ok {
+			return n, k
+		}
+	}
+	return 0, ""
+}
+
+func isRomajiVowel(r rune) bool {
+	return strings.ContainsRune("aiueo", r)
+}
+
+func isRomajiConsonant(r rune) bool {
+	return r >= 'a' && r <= 'z' && !isRomajiVowel(r) && r != 'n'
+}
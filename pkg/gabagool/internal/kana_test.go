@@ -0,0 +1,57 @@
+package internal
+
+import "testing"
+
+func TestRomajiToKana(t *testing.T) {
+	tests := []struct {
+		in          string
+		katakana    bool
+		wantKana    string
+		wantPending string
+	}{
+		{in: "ka", wantKana: "か"},
+		{in: "k", wantPending: "k"},
+		{in: "ky", wantPending: "ky"},
+		{in: "kyo", wantKana: "きょ"},
+		{in: "sushi", wantKana: "すし"},
+		{in: "kitte", wantKana: "きって"},
+		{in: "matcha", wantKana: "まっちゃ"},
+		{in: "kanji", wantKana: "かんじ"},
+		{in: "kan", wantKana: "か", wantPending: "n"},
+		{in: "konnnichiha", wantKana: "こんにちは"},
+		{in: "kan'i", wantKana: "かんい"},
+		{in: "ge-mu", katakana: true, wantKana: "ゲーム"},
+		{in: "Famikon", katakana: true, wantKana: "ファミコ", wantPending: "n"},
+		{in: "q", wantKana: "q"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			kana, pending := RomajiToKana(tt.in, tt.katakana)
+			if kana != tt.wantKana || pending != tt.wantPending {
+				t.Errorf("RomajiToKana(%q) = (%q, %q), want (%q, %q)", tt.in, kana, pending, tt.wantKana, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestFlushRomaji(t *testing.T) {
+	if got := FlushRomaji("n", false); got != "ん" {
+		t.Errorf("FlushRomaji(n) = %q, want ん", got)
+	}
+	if got := FlushRomaji("n", true); got != "ン" {
+		t.Errorf("FlushRomaji(n, katakana) = %q, want ン", got)
+	}
+	if got := FlushRomaji("ky", false); got != "ky" {
+		t.Errorf("FlushRomaji(ky) = %q, want ky", got)
+	}
+}
+
+func TestKanaScriptConversion(t *testing.T) {
+	if got := ToKatakana("ひらがな、ゔ"); got != "ヒラガナ、ヴ" {
+		t.Errorf("ToKatakana() = %q", got)
+	}
+	if got := ToHiragana("カタカナー"); got != "かたかなー" {
+		t.Errorf("ToHiragana() = %q", got)
+	}
+}
@@ -0,0 +1,461 @@
+package internal
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MarkdownBlockKind identifies a block level Markdown element.
+type MarkdownBlockKind int
+
+const (
+	MarkdownParagraph MarkdownBlockKind = iota
+	MarkdownHeading
+	MarkdownListItem
+	MarkdownCodeBlock
+	MarkdownRule
+)
+
+// MarkdownStyle is a set of inline styles.
+type MarkdownStyle uint8
+
+const (
+	MarkdownBold MarkdownStyle = 1 << iota
+	MarkdownItalic
+	MarkdownCode
+	MarkdownLink
+)
+
+// MarkdownRun is a piece of inline text in a single style.
+type MarkdownRun struct {
+	Text  string
+	Style MarkdownStyle
+}
+
+// MarkdownBlock is one block of a parsed document.
+type MarkdownBlock struct {
+	Kind   MarkdownBlockKind
+	Level  int           // Heading level, 1-6
+	Depth  int           // List nesting, 0 for top-level items
+	Marker string        // List bullet ("•") or number ("3.")
+	Quote  int           // Block quote nesting
+	Runs   []MarkdownRun // Inline content; code blocks hold a single unstyled run
+}
+
+// ParseMarkdown parses the CommonMark subset shown on detail screens: ATX and setext
+// headings, paragraphs, bullet and ordered lists, block quotes, fenced code blocks and
+// thematic breaks, with emphasis, code spans and links inline. Anything else is kept
+// as paragraph text.
+func ParseMarkdown(source string) []MarkdownBlock {
+	source = strings.ReplaceAll(strings.ReplaceAll(source, "\r\n", "\n"), "\r", "\n")
+	return parseMarkdownLines(strings.Split(source, "\n"))
+}
+
+type markdownParser struct {
+	blocks      []MarkdownBlock
+	pending     *MarkdownBlock // Open paragraph or list item
+	lines       []string       // Lines of the pending block
+	listIndents []int          // Indentation of the enclosing list items
+}
+
+func parseMarkdownLines(lines []string) []MarkdownBlock {
+	p := &markdownParser{}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		indent := markdownIndent(line)
+
+		if trimmed == "" {
+			p.flush()
+			continue
+		}
+
+		if fence := markdownFence(trimmed); fence != "" {
+			p.closeList()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			p.add(MarkdownBlock{Kind: MarkdownCodeBlock, Runs: []MarkdownRun{{Text: strings.Join(code, "\n")}}})
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, ">") {
+			p.closeList()
+			var quoted []string
+			for ; i < len(lines); i++ {
+				t := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(t, ">") {
+					break
+				}
+				t = strings.TrimPrefix(t[1:], " ")
+				quoted = append(quoted, t)
+			}
+			i--
+			for _, block := range parseMarkdownLines(quoted) {
+				block.Quote++
+				p.blocks = append(p.blocks, block)
+			}
+			continue
+		}
+
+		if level, text, ok := markdownHeading(trimmed); ok {
+			p.closeList()
+			p.add(MarkdownBlock{Kind: MarkdownHeading, Level: level, Runs: ParseMarkdownInline(text)})
+			continue
+		}
+
+		if p.pending != nil && p.pending.Kind == MarkdownParagraph && markdownSetextUnderline(trimmed) {
+			p.pending.Kind = MarkdownHeading
+			p.pending.Level = 2
+			if trimmed[0] == '=' {
+				p.pending.Level = 1
+			}
+			p.flush()
+			continue
+		}
+
+		if markdownRule(trimmed) {
+			p.closeList()
+			p.add(MarkdownBlock{Kind: MarkdownRule})
+			continue
+		}
+
+		// Only bullets and lists starting at 1 interrupt a paragraph, so a line that
+		// happens to begin with a year stays part of the text
+		if marker, text, ok := markdownListItem(trimmed); ok && (p.pending == nil || p.pending.Kind != MarkdownParagraph || marker == "•" || marker == "1.") {
+			p.flush()
+			for len(p.listIndents) > 0 && p.listIndents[len(p.listIndents)-1] >= indent {
+				p.listIndents = p.listIndents[:len(p.listIndents)-1]
+			}
+			p.pending = &MarkdownBlock{Kind: MarkdownListItem, Depth: len(p.listIndents), Marker: marker}
+			p.lines = []string{text}
+			p.listIndents = append(p.listIndents, indent)
+			continue
+		}
+
+		// Lazy continuation of the open paragraph or list item
+		if p.pending == nil {
+			p.closeList()
+			p.pending = &MarkdownBlock{Kind: MarkdownParagraph}
+		}
+		p.lines = append(p.lines, line)
+	}
+
+	p.flush()
+	return p.blocks
+}
+
+func (p *markdownParser) add(block MarkdownBlock) {
+	p.flush()
+	p.blocks = append(p.blocks, block)
+}
+
+// flush closes the pending block. Lines ending in two spaces or a backslash keep
+// their line break; other lines are joined with a space.
+func (p *markdownParser) flush() {
+	if p.pending == nil {
+		return
+	}
+
+	var text strings.Builder
+	for i, line := range p.lines {
+		hardBreak := strings.HasSuffix(line, "  ") || strings.HasSuffix(strings.TrimSpace(line), "\\")
+		line = strings.TrimSpace(line)
+		if i == len(p.lines)-1 {
+			text.WriteString(line)
+			break
+		}
+		if hardBreak {
+			text.WriteString(strings.TrimSuffix(line, "\\"))
+			text.WriteString("\n")
+		} else {
+			text.WriteString(line)
+			text.WriteString(" ")
+		}
+	}
+
+	p.pending.Runs = ParseMarkdownInline(text.String())
+	p.blocks = append(p.blocks, *p.pending)
+	p.pending = nil
+	p.lines = nil
+}
+
+func (p *markdownParser) closeList() {
+	p.flush()
+	p.listIndents = nil
+}
+
+// markdownIndent returns the width of the leading whitespace, counting tabs as four columns.
+func markdownIndent(line string) int {
+	indent := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			indent++
+		case '\t':
+			indent += 4
+		default:
+			return indent
+		}
+	}
+	return indent
+}
+
+func markdownFence(line string) string {
+	for _, fence := range []string{"```", "~~~"} {
+		if strings.HasPrefix(line, fence) {
+			return fence
+		}
+	}
+	return ""
+}
+
+func markdownHeading(line string) (int, string, bool) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ' && line[level] != '\t') {
+		return 0, "", false
+	}
+
+	text := strings.TrimSpace(line[level:])
+	// Drop an optional closing sequence of #s
+	if stripped := strings.TrimRight(text, "#"); stripped == "" || strings.HasSuffix(stripped, " ") {
+		text = strings.TrimSpace(stripped)
+	}
+	return level, text, true
+}
+
+func markdownSetextUnderline(line string) bool {
+	return strings.Trim(line, "=") == "" || strings.Trim(line, "-") == ""
+}
+
+func markdownRule(line string) bool {
+	if c := line[0]; c != '-' && c != '*' && c != '_' {
+		return false
+	}
+	count := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case line[0]:
+			count++
+		case ' ', '\t':
+		default:
+			return false
+		}
+	}
+	return count >= 3
+}
+
+func markdownListItem(line string) (string, string, bool) {
+	if c := line[0]; c == '-' || c == '*' || c == '+' {
+		if len(line) == 1 || line[1] == ' ' || line[1] == '\t' {
+			return "•", strings.TrimSpace(line[1:]), true
+		}
+		return "", "", false
+	}
+
+	digits := 0
+	for digits < len(line) && digits < 9 && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits == 0 || digits >= len(line) || (line[digits] != '.' && line[digits] != ')') {
+		return "", "", false
+	}
+	if rest := line[digits+1:]; rest == "" || rest[0] == ' ' || rest[0] == '\t' {
+		return line[:digits] + ".", strings.TrimSpace(rest), true
+	}
+	return "", "", false
+}
+
+// ParseMarkdownInline splits inline Markdown into styled runs. Emphasis uses * or _
+// (doubled for bold), code spans use backticks, and links and images keep only their
+// text. Backslash escapes punctuation and unmatched delimiters are kept as text.
+// Adjacent runs never share a style.
+func ParseMarkdownInline(text string) []MarkdownRun {
+	var runs []MarkdownRun
+	parseMarkdownInline([]rune(text), 0, &runs)
+	return runs
+}
+
+func parseMarkdownInline(text []rune, style MarkdownStyle, runs *[]MarkdownRun) {
+	var buf []rune
+	flush := func() {
+		appendMarkdownRun(runs, string(buf), style)
+		buf = buf[:0]
+	}
+
+	for i := 0; i < len(text); i++ {
+		r := text[i]
+		switch {
+		case r == '\\' && i+1 < len(text) && (unicode.IsPunct(text[i+1]) || unicode.IsSymbol(text[i+1])):
+			buf = append(buf, text[i+1])
+			i++
+
+		case r == '`':
+			n := markdownRunLength(text, i)
+			if end := markdownCodeSpanEnd(text, i+n, n); end >= 0 {
+				flush()
+				code := string(text[i+n : end])
+				if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				appendMarkdownRun(runs, code, style|MarkdownCode)
+				i = end + n - 1
+				continue
+			}
+			buf = append(buf, text[i:i+n]...)
+			i += n - 1
+
+		case r == '[' || (r == '!' && i+1 < len(text) && text[i+1] == '['):
+			start := i
+			if r == '!' {
+				start++
+			}
+			if label, end, ok := markdownLink(text, start); ok {
+				flush()
+				if r == '!' {
+					appendMarkdownRun(runs, string(label), style)
+				} else {
+					parseMarkdownInline(label, style|MarkdownLink, runs)
+				}
+				i = end - 1
+				continue
+			}
+			buf = append(buf, r)
+
+		case r == '*' || r == '_':
+			n := min(markdownRunLength(text, i), 2)
+			if end := markdownEmphasisEnd(text, i, n); end >= 0 {
+				flush()
+				emphasis := MarkdownItalic
+				if n == 2 {
+					emphasis = MarkdownBold
+				}
+				parseMarkdownInline(text[i+n:end], style|emphasis, runs)
+				i = end + n - 1
+				continue
+			}
+			n = markdownRunLength(text, i)
+			buf = append(buf, text[i:i+n]...)
+			i += n - 1
+
+		default:
+			buf = append(buf, r)
+		}
+	}
+
+	flush()
+}
+
+func appendMarkdownRun(runs *[]MarkdownRun, text string, style MarkdownStyle) {
+	if text == "" {
+		return
+	}
+	if last := len(*runs) - 1; last >= 0 && (*runs)[last].Style == style {
+		(*runs)[last].Text += text
+		return
+	}
+	*runs = append(*runs, MarkdownRun{Text: text, Style: style})
+}
+
+func markdownRunLength(text []rune, i int) int {
+	n := 1
+	for i+n < len(text) && text[i+n] == text[i] {
+		n++
+	}
+	return n
+}
+
+// markdownCodeSpanEnd finds the closing backtick run of exactly n backticks.
+func markdownCodeSpanEnd(text []rune, from, n int) int {
+	for i := from; i < len(text); i++ {
+		if text[i] != '`' {
+			continue
+		}
+		m := markdownRunLength(text, i)
+		if m == n {
+			return i
+		}
+		i += m - 1
+	}
+	return -1
+}
+
+// markdownEmphasisEnd returns where the emphasis opened by n delimiters at i closes,
+// or -1 if they don't open emphasis. Underscores inside words are literal, so
+// snake_case stays intact.
+func markdownEmphasisEnd(text []rune, i, n int) int {
+	delimiter := text[i]
+	isWord := func(pos int) bool {
+		return pos >= 0 && pos < len(text) && (unicode.IsLetter(text[pos]) || unicode.IsDigit(text[pos]))
+	}
+
+	if i+n >= len(text) || unicode.IsSpace(text[i+n]) {
+		return -1
+	}
+	if delimiter == '_' && isWord(i-1) {
+		return -1
+	}
+
+	for k := i + n + 1; k < len(text); k++ {
+		switch text[k] {
+		case '\\':
+			k++
+			continue
+		case '`':
+			m := markdownRunLength(text, k)
+			if end := markdownCodeSpanEnd(text, k+m, m); end >= 0 {
+				k = end + m - 1
+			}
+			continue
+		case delimiter:
+		default:
+			continue
+		}
+
+		m := markdownRunLength(text, k)
+		// A single delimiter can't close on a bold pair, and the closer is the last
+		// n delimiters of the run, so ***text*** nests italic inside bold
+		closes := m >= n && !(n == 1 && m == 2) &&
+			!unicode.IsSpace(text[k-1]) &&
+			(delimiter != '_' || !isWord(k+m))
+		if closes {
+			return k + m - n
+		}
+		k += m - 1
+	}
+	return -1
+}
+
+// markdownLink parses [label](destination) starting at the opening bracket and
+// returns the label and the index after the closing parenthesis.
+func markdownLink(text []rune, start int) ([]rune, int, bool) {
+	depth := 0
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if i+1 >= len(text) || text[i+1] != '(' {
+				return nil, 0, false
+			}
+			for j := i + 2; j < len(text); j++ {
+				if text[j] == ')' {
+					return text[start+1 : i], j + 1, true
+				}
+			}
+			return nil, 0, false
+		}
+	}
+	return nil, 0, false
+}
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMarkdownInline(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []MarkdownRun
+	}{
+		{name: "plain", text: "hello world", want: []MarkdownRun{{Text: "hello world"}}},
+		{name: "bold", text: "a **b** c", want: []MarkdownRun{{Text: "a "}, {Text: "b", Style: MarkdownBold}, {Text: " c"}}},
+		{name: "italic underscore", text: "_b_", want: []MarkdownRun{{Text: "b", Style: MarkdownItalic}}},
+		{name: "bold italic", text: "***b***", want: []MarkdownRun{{Text: "b", Style: MarkdownBold | MarkdownItalic}}},
+		{name: "nested", text: "*a **b** c*", want: []MarkdownRun{
+			{Text: "a ", Style: MarkdownItalic},
+			{Text: "b", Style: MarkdownItalic | MarkdownBold},
+			{Text: " c", Style: MarkdownItalic},
+		}},
+		{name: "code", text: "run `make *all*` now", want: []MarkdownRun{{Text: "run "}, {Text: "make *all*", Style: MarkdownCode}, {Text: " now"}}},
+		{name: "double backtick code", text: "`` a`b ``", want: []MarkdownRun{{Text: "a`b", Style: MarkdownCode}}},
+		{name: "link", text: "see [the **docs**](https://example.com).", want: []MarkdownRun{
+			{Text: "see "},
+			{Text: "the ", Style: MarkdownLink},
+			{Text: "docs", Style: MarkdownLink | MarkdownBold},
+			{Text: "."},
+		}},
+		{name: "image keeps alt text", text: "![logo](logo.png)", want: []MarkdownRun{{Text: "logo"}}},
+		{name: "snake case", text: "snake_case_name", want: []MarkdownRun{{Text: "snake_case_name"}}},
+		{name: "spaced asterisk", text: "2 * 3 * 4", want: []MarkdownRun{{Text: "2 * 3 * 4"}}},
+		{name: "unmatched", text: "**open", want: []MarkdownRun{{Text: "**open"}}},
+		{name: "escape", text: `\*not\*`, want: []MarkdownRun{{Text: "*not*"}}},
+		{name: "empty", text: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseMarkdownInline(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMarkdownInline(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	plain := func(text string) []MarkdownRun { return []MarkdownRun{{Text: text}} }
+
+	tests := []struct {
+		name   string
+		source string
+		want   []MarkdownBlock
+	}{
+		{
+			name:   "headings",
+			source: "# One #\n### Three\n#hashtag",
+			want: []MarkdownBlock{
+				{Kind: MarkdownHeading, Level: 1, Runs: plain("One")},
+				{Kind: MarkdownHeading, Level: 3, Runs: plain("Three")},
+				{Kind: MarkdownParagraph, Runs: plain("#hashtag")},
+			},
+		},
+		{
+			name:   "setext headings",
+			source: "Title\n=====\nSub\n---",
+			want: []MarkdownBlock{
+				{Kind: MarkdownHeading, Level: 1, Runs: plain("Title")},
+				{Kind: MarkdownHeading, Level: 2, Runs: plain("Sub")},
+			},
+		},
+		{
+			name:   "paragraphs join lines",
+			source: "one\ntwo  \nthree\n\nfour",
+			want: []MarkdownBlock{
+				{Kind: MarkdownParagraph, Runs: plain("one two\nthree")},
+				{Kind: MarkdownParagraph, Runs: plain("four")},
+			},
+		},
+		{
+			name:   "lists",
+			source: "- a\n  continued\n  1. b\n  2) c\n* d",
+			want: []MarkdownBlock{
+				{Kind: MarkdownListItem, Marker: "•", Runs: plain("a continued")},
+				{Kind: MarkdownListItem, Depth: 1, Marker: "1.", Runs: plain("b")},
+				{Kind: MarkdownListItem, Depth: 1, Marker: "2.", Runs: plain("c")},
+				{Kind: MarkdownListItem, Marker: "•", Runs: plain("d")},
+			},
+		},
+		{
+			name:   "rules",
+			source: "---\n* * *\n___",
+			want: []MarkdownBlock{
+				{Kind: MarkdownRule},
+				{Kind: MarkdownRule},
+				{Kind: MarkdownRule},
+			},
+		},
+		{
+			name:   "code block",
+			source: "```go\nfunc main() {\n    **not bold**\n}\n```\nafter",
+			want: []MarkdownBlock{
+				{Kind: MarkdownCodeBlock, Runs: plain("func main() {\n    **not bold**\n}")},
+				{Kind: MarkdownParagraph, Runs: plain("after")},
+			},
+		},
+		{
+			name:   "block quote",
+			source: "> # Note\n> quoted\n>> deeper\n\nafter",
+			want: []MarkdownBlock{
+				{Kind: MarkdownHeading, Level: 1, Quote: 1, Runs: plain("Note")},
+				{Kind: MarkdownParagraph, Quote: 1, Runs: plain("quoted")},
+				{Kind: MarkdownParagraph, Quote: 2, Runs: plain("deeper")},
+				{Kind: MarkdownParagraph, Runs: plain("after")},
+			},
+		},
+		{
+			name:   "not a list",
+			source: "-5 degrees\n2024. A year",
+			want: []MarkdownBlock{
+				{Kind: MarkdownParagraph, Runs: plain("-5 degrees 2024. A year")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseMarkdown(tt.source)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMarkdown(%q) =\n%+v\nwant\n%+v", tt.source, got, tt.want)
+			}
+		})
+	}
+}
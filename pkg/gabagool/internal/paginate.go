@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PaginateRows splits rows of text into pages no taller than pageHeight. Row i spans
+// tops[i] to bottoms[i], in ascending order. It returns the index of the first row of
+// each page; a row taller than a page gets a page to itself.
+func PaginateRows(tops, bottoms []int32, pageHeight int32) []int {
+	pages := []int{0}
+	if len(tops) == 0 {
+		return pages
+	}
+
+	pageTop := tops[0]
+	for i := 1; i < len(tops); i++ {
+		if bottoms[i]-pageTop > pageHeight {
+			pages = append(pages, i)
+			pageTop = tops[i]
+		}
+	}
+	return pages
+}
+
+// FindFold returns the rune offsets of the non-overlapping matches of query in text,
+// ignoring case.
+func FindFold(text, query string) []int {
+	haystack, needle := []rune(text), []rune(query)
+	if len(needle) == 0 {
+		return nil
+	}
+
+	var matches []int
+	for i := 0; i+len(needle) <= len(haystack); {
+		matched := true
+		for j, r := range needle {
+			if unicode.ToLower(haystack[i+j]) != unicode.ToLower(r) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, i)
+			i += len(needle)
+		} else {
+			i++
+		}
+	}
+	return matches
+}
+
+// TextPart is a range of runes within one of several texts.
+type TextPart struct {
+	Index int // which text
+	Start int
+	End   int
+}
+
+// FindFoldJoined finds query, ignoring case, in texts joined end to end with joins[i]
+// between texts[i-1] and texts[i], so a match may run from one text into the next.
+// Each match is returned as the parts of the texts it covers; matches made only of
+// join characters are left out.
+func FindFoldJoined(texts, joins []string, query string) [][]TextPart {
+	var joined []rune
+	starts := make([]int, len(texts))
+	ends := make([]int, len(texts))
+	for i, text := range texts {
+		if i > 0 {
+			joined = append(joined, []rune(joins[i])...)
+		}
+		starts[i] = len(joined)
+		joined = append(joined, []rune(text)...)
+		ends[i] = len(joined)
+	}
+
+	length := utf8.RuneCountInString(query)
+	var matches [][]TextPart
+	for _, start := range FindFold(string(joined), query) {
+		end := start + length
+
+		var parts []TextPart
+		first := sort.Search(len(starts), func(i int) bool { return ends[i] > start })
+		for i := first; i < len(texts) && starts[i] < end; i++ {
+			from, to := max(start, starts[i]), min(end, ends[i])
+			if from < to {
+				parts = append(parts, TextPart{Index: i, Start: from - starts[i], End: to - starts[i]})
+			}
+		}
+		if len(parts) > 0 {
+			matches = append(matches, parts)
+		}
+	}
+	return matches
+}
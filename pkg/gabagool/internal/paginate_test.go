@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPaginateRows(t *testing.T) {
+	tests := []struct {
+		name       string
+		tops       []int32
+		bottoms    []int32
+		pageHeight int32
+		want       []int
+	}{
+		{name: "empty", want: []int{0}},
+		{name: "single page", tops: []int32{0, 25, 50}, bottoms: []int32{20, 45, 70}, pageHeight: 100, want: []int{0}},
+		{name: "exact fit", tops: []int32{0, 25, 50, 75}, bottoms: []int32{20, 45, 70, 95}, pageHeight: 95, want: []int{0}},
+		{name: "breaks between rows", tops: []int32{0, 25, 50, 75, 100}, bottoms: []int32{20, 45, 70, 95, 120}, pageHeight: 50, want: []int{0, 2, 4}},
+		{name: "tall row gets its own page", tops: []int32{0, 25, 250}, bottoms: []int32{20, 240, 270}, pageHeight: 100, want: []int{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PaginateRows(tt.tops, tt.bottoms, tt.pageHeight); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PaginateRows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindFold(t *testing.T) {
+	tests := []struct {
+		text, query string
+		want        []int
+	}{
+		{"Press START to begin", "start", []int{6}},
+		{"aaaa", "aa", []int{0, 2}},
+		{"Ünïcode ÜNÏ", "ünï", []int{0, 8}},
+		{"nothing here", "missing", nil},
+		{"anything", "", nil},
+	}
+
+	for _, tt := range tests {
+		if got := FindFold(tt.text, tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FindFold(%q, %q) = %v, want %v", tt.text, tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFindFoldJoined(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		joins []string
+		query string
+		want  [][]TextPart
+	}{
+		{
+			name:  "within one text",
+			texts: []string{"Press ", "START", " to begin"},
+			joins: []string{"", "", ""},
+			query: "begin",
+			want:  [][]TextPart{{{Index: 2, Start: 4, End: 9}}},
+		},
+		{
+			name:  "across a change of style",
+			texts: []string{"Press ", "START", " to begin"},
+			joins: []string{"", "", ""},
+			query: "press start",
+			want:  [][]TextPart{{{Index: 0, Start: 0, End: 6}, {Index: 1, Start: 0, End: 5}}},
+		},
+		{
+			name:  "across a line wrap",
+			texts: []string{"the quick", "brown fox"},
+			joins: []string{"", " "},
+			query: "quick brown",
+			want:  [][]TextPart{{{Index: 0, Start: 4, End: 9}, {Index: 1, Start: 0, End: 5}}},
+		},
+		{
+			name:  "over a whole text",
+			texts: []string{"a", "b", "c"},
+			joins: []string{"", "", ""},
+			query: "ABC",
+			want:  [][]TextPart{{{Index: 0, Start: 0, End: 1}, {Index: 1, Start: 0, End: 1}, {Index: 2, Start: 0, End: 1}}},
+		},
+		{
+			name:  "several matches",
+			texts: []string{"ab", "ab"},
+			joins: []string{"", " "},
+			query: "b",
+			want:  [][]TextPart{{{Index: 0, Start: 1, End: 2}}, {{Index: 1, Start: 1, End: 2}}},
+		},
+		{
+			name:  "only a join",
+			texts: []string{"a", "b"},
+			joins: []string{"", " "},
+			query: " ",
+			want:  nil,
+		},
+		{
+			name:  "no match",
+			texts: []string{"a", "b"},
+			joins: []string{"", ""},
+			query: "c",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindFoldJoined(tt.texts, tt.joins, tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindFoldJoined = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
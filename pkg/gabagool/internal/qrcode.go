@@ -0,0 +1,481 @@
+package internal
+
+import "fmt"
+
+// QR code encoding in byte mode, following ISO/IEC 18004. Versions 1-40 are chosen
+// automatically from the data length and the mask with the lowest penalty is used.
+
+// QRErrorCorrection is a QR code error correction level.
+type QRErrorCorrection int
+
+const (
+	QRErrorCorrectionLow      QRErrorCorrection = iota // Recovers ~7% of the symbol
+	QRErrorCorrectionMedium                            // Recovers ~15%
+	QRErrorCorrectionQuartile                          // Recovers ~25%
+	QRErrorCorrectionHigh                              // Recovers ~30%
+)
+
+// QRQuietZone is the number of light modules required around a QR code.
+const QRQuietZone = 4
+
+// QRCode is an encoded symbol. Modules are addressed by column and row.
+type QRCode struct {
+	Size    int
+	modules [][]bool
+}
+
+// Dark reports whether the module at column x and row y is dark.
+// Coordinates outside the symbol, such as the quiet zone, are light.
+func (q *QRCode) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= q.Size || y >= q.Size {
+		return false
+	}
+	return q.modules[y][x]
+}
+
+// Error correction codewords per block and number of blocks, indexed by level and version.
+var qrECCCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+var qrErrorCorrectionBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// Format information bits for each level; not in level order.
+var qrFormatBits = [4]int{1, 0, 3, 2}
+
+// EncodeQR encodes data into the smallest QR code that holds it at the given level.
+func EncodeQR(data []byte, level QRErrorCorrection) (*QRCode, error) {
+	if level < QRErrorCorrectionLow || level > QRErrorCorrectionHigh {
+		return nil, fmt.Errorf("invalid QR error correction level %d", level)
+	}
+
+	version := 0
+	for v := 1; v <= 40; v++ {
+		if qrByteModeBits(v, len(data)) <= qrDataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("%d bytes is too long for a QR code", len(data))
+	}
+
+	codewords := qrAddErrorCorrection(qrDataBits(data, version, level), version, level)
+
+	q := newQRSymbol(version)
+	q.drawFunctionPatterns(version, level)
+	q.drawCodewords(codewords)
+
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(level, mask)
+		if penalty := q.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		q.applyMask(mask) // Masking is its own inverse
+	}
+	q.applyMask(bestMask)
+	q.drawFormatBits(level, bestMask)
+
+	return &QRCode{Size: q.size, modules: q.modules}, nil
+}
+
+func qrByteModeBits(version, length int) int {
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	return 4 + countBits + length*8
+}
+
+// qrRawDataModules returns the number of modules left for data and error correction
+// after the function patterns of a version.
+func qrRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		alignments := version/7 + 2
+		result -= (25*alignments-10)*alignments - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+func qrDataCodewords(version int, level QRErrorCorrection) int {
+	return qrRawDataModules(version)/8 - qrECCCodewordsPerBlock[level][version]*qrErrorCorrectionBlocks[level][version]
+}
+
+// qrDataBits builds the data codewords: mode, length, data, terminator and padding.
+func qrDataBits(data []byte, version int, level QRErrorCorrection) []byte {
+	capacity := qrDataCodewords(version, level)
+	var bits qrBitBuffer
+
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	bits.append(0x4, 4)
+	bits.append(len(data), countBits)
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	bits.append(0, min(4, capacity*8-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity*8; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	codewords := make([]byte, capacity)
+	for i, bit := range bits {
+		if bit {
+			codewords[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return codewords
+}
+
+type qrBitBuffer []bool
+
+func (b *qrBitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 != 0)
+	}
+}
+
+// qrAddErrorCorrection splits data into blocks, appends the Reed-Solomon codewords
+// of each and interleaves the result.
+func qrAddErrorCorrection(data []byte, version int, level QRErrorCorrection) []byte {
+	numBlocks := qrErrorCorrectionBlocks[level][version]
+	eccLength := qrECCCodewordsPerBlock[level][version]
+	rawCodewords := qrRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLength := rawCodewords / numBlocks
+
+	divisor := ReedSolomonDivisor(eccLength)
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := range blocks {
+		dataLength := shortBlockLength - eccLength
+		if i >= numShortBlocks {
+			dataLength++
+		}
+		block := append([]byte(nil), data[k:k+dataLength]...)
+		k += dataLength
+		ecc := ReedSolomonRemainder(block, divisor)
+		if i < numShortBlocks {
+			block = append(block, 0) // Keeps every block the same length for interleaving
+		}
+		blocks[i] = append(block, ecc...)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortBlockLength-eccLength || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// ReedSolomonDivisor returns the generator polynomial of the given degree over
+// GF(2^8/0x11D), highest coefficient first with the leading 1 omitted.
+func ReedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = qrMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrMultiply(root, 0x02)
+	}
+	return result
+}
+
+// ReedSolomonRemainder returns the error correction codewords of data.
+func ReedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= qrMultiply(d, factor)
+		}
+	}
+	return result
+}
+
+func qrMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// qrSymbol is a QR code under construction.
+type qrSymbol struct {
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+func newQRSymbol(version int) *qrSymbol {
+	size := version*4 + 17
+	q := &qrSymbol{size: size, modules: make([][]bool, size), isFunction: make([][]bool, size)}
+	for i := range q.modules {
+		q.modules[i] = make([]bool, size)
+		q.isFunction[i] = make([]bool, size)
+	}
+	return q
+}
+
+func (q *qrSymbol) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.isFunction[y][x] = true
+}
+
+func (q *qrSymbol) drawFunctionPatterns(version int, level QRErrorCorrection) {
+	for i := 0; i < q.size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	q.drawFinder(3, 3)
+	q.drawFinder(q.size-4, 3)
+	q.drawFinder(3, q.size-4)
+
+	positions := qrAlignmentPositions(version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// Skip the corners taken by finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.setFunction(x+dx, y+dy, max(Abs(dx), Abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas; the real bits are drawn once the mask is known
+	q.drawFormatBits(level, 0)
+
+	if version >= 7 {
+		remainder := version
+		for i := 0; i < 12; i++ {
+			remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1F25)
+		}
+		bits := version<<12 | remainder
+		for i := 0; i < 18; i++ {
+			dark := (bits>>i)&1 != 0
+			a, b := q.size-11+i%3, i/3
+			q.setFunction(a, b, dark)
+			q.setFunction(b, a, dark)
+		}
+	}
+}
+
+// drawFinder draws a finder pattern and its separator centered on (x, y).
+func (q *qrSymbol) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= q.size || yy >= q.size {
+				continue
+			}
+			distance := max(Abs(dx), Abs(dy))
+			q.setFunction(xx, yy, distance != 2 && distance != 4)
+		}
+	}
+}
+
+func qrAlignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	count := version/7 + 2
+	step := (version*8 + count*3 + 5) / (count*4 - 4) * 2
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, pos := count-1, version*4+10; i > 0; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+	return positions
+}
+
+func (q *qrSymbol) drawFormatBits(level QRErrorCorrection, mask int) {
+	bits := QRFormatBits(level, mask)
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+
+	// Around the top left finder
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+
+	// Split between the other two finders
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(i))
+	}
+	q.setFunction(8, q.size-8, true)
+}
+
+// QRFormatBits returns the 15 masked format information bits for a level and mask.
+func QRFormatBits(level QRErrorCorrection, mask int) int {
+	data := qrFormatBits[level]<<3 | mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	return (data<<10 | remainder) ^ 0x5412
+}
+
+// drawCodewords places the codewords in the zigzag order of the standard, two columns
+// at a time from the bottom right, skipping function modules.
+func (q *qrSymbol) drawCodewords(codewords []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vertical := 0; vertical < q.size; vertical++ {
+			y := vertical
+			if upward {
+				y = q.size - 1 - vertical
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if q.isFunction[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				q.modules[y][x] = (codewords[i>>3]>>(7-i&7))&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+func (q *qrSymbol) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the symbol is to scan: long runs, 2x2 blocks, finder-like
+// patterns and an unbalanced share of dark modules all add to it.
+func (q *qrSymbol) penalty() int {
+	score := 0
+	at := func(x, y int, transposed bool) bool {
+		if transposed {
+			return q.modules[x][y]
+		}
+		return q.modules[y][x]
+	}
+
+	finderLike := []bool{true, false, true, true, true, false, true, false, false, false, false}
+	for _, transposed := range []bool{false, true} {
+		for y := 0; y < q.size; y++ {
+			run := 1
+			for x := 1; x <= q.size; x++ {
+				if x < q.size && at(x, y, transposed) == at(x-1, y, transposed) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+
+			for x := 0; x+len(finderLike) <= q.size; x++ {
+				forward, backward := true, true
+				for k, dark := range finderLike {
+					forward = forward && at(x+k, y, transposed) == dark
+					backward = backward && at(x+len(finderLike)-1-k, y, transposed) == dark
+				}
+				if forward {
+					score += 40
+				}
+				if backward {
+					score += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x > 0 && y > 0 {
+				c := q.modules[y][x]
+				if c == q.modules[y][x-1] && c == q.modules[y-1][x] && c == q.modules[y-1][x-1] {
+					score += 3
+				}
+			}
+		}
+	}
+
+	total := q.size * q.size
+	score += ((Abs(dark*20-total*10)+total-1)/total - 1) * 10
+	return score
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// "HELLO WORLD" as version 1-M in alphanumeric mode
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := ReedSolomonRemainder(data, ReedSolomonDivisor(len(want))); !bytes.Equal(got, want) {
+		t.Errorf("ReedSolomonRemainder = %v, want %v", got, want)
+	}
+}
+
+func TestQRFormatBits(t *testing.T) {
+	tests := []struct {
+		level QRErrorCorrection
+		mask  int
+		want  int
+	}{
+		{QRErrorCorrectionLow, 4, 0b110011000101111},
+		{QRErrorCorrectionMedium, 0, 0b101010000010010},
+		{QRErrorCorrectionQuartile, 7, 0b010101111101101},
+		{QRErrorCorrectionHigh, 2, 0b001110011100111},
+	}
+
+	for _, tt := range tests {
+		if got := QRFormatBits(tt.level, tt.mask); got != tt.want {
+			t.Errorf("QRFormatBits(%d, %d) = %015b, want %015b", tt.level, tt.mask, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeQR(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		level    QRErrorCorrection
+		wantSize int
+	}{
+		{name: "version 1", data: "hello", level: QRErrorCorrectionMedium, wantSize: 21},
+		{name: "version 2", data: "https://example.com", level: QRErrorCorrectionMedium, wantSize: 25},
+		{name: "level raises version", data: "https://example.com", level: QRErrorCorrectionHigh, wantSize: 29},
+		{name: "version info", data: strings.Repeat("a", 200), level: QRErrorCorrectionLow, wantSize: 53},
+		{name: "long count field", data: strings.Repeat("a", 400), level: QRErrorCorrectionMedium, wantSize: 77},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := EncodeQR([]byte(tt.data), tt.level)
+			if err != nil {
+				t.Fatalf("EncodeQR: %v", err)
+			}
+			if q.Size != tt.wantSize {
+				t.Errorf("Size = %d, want %d", q.Size, tt.wantSize)
+			}
+
+			// Finder patterns: dark outer ring, light inner ring, dark center, light separator
+			for _, corner := range [][2]int{{3, 3}, {q.Size - 4, 3}, {3, q.Size - 4}} {
+				x, y := corner[0], corner[1]
+				if !q.Dark(x, y) || !q.Dark(x-3, y) || q.Dark(x-2, y) || q.Dark(x, y+4) {
+					t.Errorf("bad finder pattern at %v", corner)
+				}
+			}
+			if !q.Dark(8, q.Size-8) {
+				t.Error("missing dark module")
+			}
+			if q.Dark(-1, 0) || q.Dark(0, q.Size) {
+				t.Error("quiet zone should be light")
+			}
+		})
+	}
+}
+
+func TestEncodeQRTooLong(t *testing.T) {
+	if _, err := EncodeQR(make([]byte, 3000), QRErrorCorrectionLow); err == nil {
+		t.Error("expected an error for data over the version 40 capacity")
+	}
+}
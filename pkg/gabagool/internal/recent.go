@@ -0,0 +1,18 @@
+package internal
+
+// PushRecent moves item to the front of list, removing any earlier copy, and caps
+// the list at limit entries (0 = unlimited). The input slice is not modified.
+func PushRecent(list []string, item string, limit int) []string {
+	result := make([]string, 0, len(list)+1)
+	result = append(result, item)
+	for _, existing := range list {
+		if existing != item {
+			result = append(result, existing)
+		}
+	}
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPushRecent(t *testing.T) {
+	tests := []struct {
+		name  string
+		list  []string
+		item  string
+		limit int
+		want  []string
+	}{
+		{name: "empty", list: nil, item: "a", limit: 3, want: []string{"a"}},
+		{name: "prepends", list: []string{"b", "c"}, item: "a", limit: 3, want: []string{"a", "b", "c"}},
+		{name: "moves duplicate to front", list: []string{"a", "b", "c"}, item: "c", limit: 3, want: []string{"c", "a", "b"}},
+		{name: "caps", list: []string{"b", "c", "d"}, item: "a", limit: 3, want: []string{"a", "b", "c"}},
+		{name: "unlimited", list: []string{"b", "c", "d"}, item: "a", limit: 0, want: []string{"a", "b", "c", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append([]string(nil), tt.list...)
+			got := PushRecent(tt.list, tt.item, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PushRecent(%q, %q, %d) = %q, want %q", tt.list, tt.item, tt.limit, got, tt.want)
+			}
+			if !reflect.DeepEqual(tt.list, original) {
+				t.Errorf("PushRecent modified its input: %q", tt.list)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CompleteWord completes the last word of prefix from words, case-insensitively and
+// in the order of words. Each result is prefix with its last word completed. Words the
+// last word already equals are left out, and at most limit results are returned.
+func CompleteWord(words []string, prefix string, limit int) []string {
+	start := LastWordStart(prefix)
+	head, word := prefix[:start], strings.ToLower(prefix[start:])
+	if word == "" {
+		return nil
+	}
+
+	var results []string
+	for _, w := range words {
+		lower := strings.ToLower(w)
+		if lower != word && strings.HasPrefix(lower, word) {
+			results = append(results, head+w)
+			if len(results) == limit {
+				break
+			}
+		}
+	}
+	return results
+}
+
+// CompleteEntry returns the entries that start with prefix, case-insensitively, in
+// order and without duplicates. An entry equal to prefix is left out, and at most
+// limit results are returned.
+func CompleteEntry(entries []string, prefix string, limit int) []string {
+	lowerPrefix := strings.ToLower(prefix)
+
+	var results []string
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if e == prefix || seen[e] || !strings.HasPrefix(strings.ToLower(e), lowerPrefix) {
+			continue
+		}
+		seen[e] = true
+		results = append(results, e)
+		if len(results) == limit {
+			break
+		}
+	}
+	return results
+}
+
+// LastWordStart returns the byte offset of the last whitespace separated word in s.
+func LastWordStart(s string) int {
+	i := strings.LastIndexFunc(s, unicode.IsSpace)
+	if i < 0 {
+		return 0
+	}
+	_, size := utf8.DecodeRuneInString(s[i:])
+	return i + size
+}
+
+// SuggestionWord returns the part of suggestion that replaces the last word of prefix.
+// A suggestion that repeats the text before that word, case-insensitively, has it cut
+// off; any other suggestion replaces the word as a whole. The cut is made in runes on
+// suggestion itself, as case folding can change byte lengths.
+func SuggestionWord(prefix, suggestion string) string {
+	head := prefix[:LastWordStart(prefix)]
+	if head == "" || !strings.HasPrefix(strings.ToLower(suggestion), strings.ToLower(head)) {
+		return suggestion
+	}
+	return string([]rune(suggestion)[utf8.RuneCountInString(head):])
+}
@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompleteWord(t *testing.T) {
+	words := []string{"Mario", "Metroid", "Mega Man", "metro", "Zelda"}
+
+	tests := []struct {
+		name   string
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{name: "keeps word list order", prefix: "me", limit: 8, want: []string{"Metroid", "Mega Man", "metro"}},
+		{name: "case-insensitive", prefix: "MAR", limit: 8, want: []string{"Mario"}},
+		{name: "completes the last word", prefix: "play z", limit: 8, want: []string{"play Zelda"}},
+		{name: "keeps earlier text as typed", prefix: "Play  ZE", limit: 8, want: []string{"Play  Zelda"}},
+		{name: "skips exact match", prefix: "metro", limit: 8, want: []string{"Metroid"}},
+		{name: "capped", prefix: "m", limit: 2, want: []string{"Mario", "Metroid"}},
+		{name: "empty input", prefix: "", limit: 8, want: nil},
+		{name: "after a space", prefix: "play ", limit: 8, want: nil},
+		{name: "no match", prefix: "sonic", limit: 8, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompleteWord(words, tt.prefix, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompleteWord(%q) = %q, want %q", tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompleteEntry(t *testing.T) {
+	entries := []string{"Pokemon Red", "pokemon blue", "Pokemon Red", "Tetris", "Pokemon"}
+
+	tests := []struct {
+		name   string
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{name: "most recent first", prefix: "pok", limit: 8, want: []string{"Pokemon Red", "pokemon blue", "Pokemon"}},
+		{name: "empty input offers all", prefix: "", limit: 8, want: []string{"Pokemon Red", "pokemon blue", "Tetris", "Pokemon"}},
+		{name: "skips exact match", prefix: "Pokemon", limit: 8, want: []string{"Pokemon Red", "pokemon blue"}},
+		{name: "case-insensitive", prefix: "TET", limit: 8, want: []string{"Tetris"}},
+		{name: "capped", prefix: "", limit: 2, want: []string{"Pokemon Red", "pokemon blue"}},
+		{name: "no match", prefix: "zelda", limit: 8, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompleteEntry(entries, tt.prefix, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompleteEntry(%q) = %q, want %q", tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastWordStart(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"word", 0},
+		{"two words", 4},
+		{"trailing ", 9},
+		{"tab\tword", 4},
+		{"wide　space", 7},
+	}
+
+	for _, tt := range tests {
+		if got := LastWordStart(tt.s); got != tt.want {
+			t.Errorf("LastWordStart(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestionWord(t *testing.T) {
+	tests := []struct {
+		prefix     string
+		suggestion string
+		want       string
+	}{
+		{"mar", "Mario", "Mario"},
+		{"super mar", "super Mario", "Mario"},
+		{"Super mar", "super Mario", "Mario"},
+		{"super mar", "Mario", "Mario"},
+		{"pokemon r", "Pokemon Red", "Red"},
+		{"ÉCOLE c", "école club", "club"},
+		{"İstanbul k", "istanbul kebap", "kebap"},
+		{"", "Tetris", "Tetris"},
+	}
+
+	for _, tt := range tests {
+		if got := SuggestionWord(tt.prefix, tt.suggestion); got != tt.want {
+			t.Errorf("SuggestionWord(%q, %q) = %q, want %q", tt.prefix, tt.suggestion, got, tt.want)
+		}
+	}
+}
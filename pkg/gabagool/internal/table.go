@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TableColumnWidths sizes table columns. natural holds the width that fits each
+// column's content and fixed the requested widths (0 = fit content). Fitted columns
+// share any leftover space in proportion to their natural width. When the columns
+// don't fit, fitted columns shrink proportionally down to minWidth, unless overflow is
+// allowed, in which case they keep their natural width and the table is wider than available.
+func TableColumnWidths(natural, fixed []int32, available, minWidth int32, overflow bool) []int32 {
+	isFitted := func(i int) bool { return i >= len(fixed) || fixed[i] <= 0 }
+
+	widths := make([]int32, len(natural))
+	var fixedTotal, fittedTotal int32
+	for i := range natural {
+		if isFitted(i) {
+			widths[i] = natural[i]
+			fittedTotal += natural[i]
+		} else {
+			widths[i] = fixed[i]
+			fixedTotal += fixed[i]
+		}
+	}
+	if fittedTotal == 0 {
+		return widths
+	}
+
+	remaining := available - fixedTotal - fittedTotal
+	switch {
+	case remaining > 0:
+		for i := range widths {
+			if isFitted(i) {
+				widths[i] += int32(int64(remaining) * int64(natural[i]) / int64(fittedTotal))
+			}
+		}
+	case remaining < 0 && !overflow:
+		space := max(available-fixedTotal, 0)
+		for i := range widths {
+			if isFitted(i) {
+				widths[i] = max(int32(int64(natural[i])*int64(space)/int64(fittedTotal)), minWidth)
+			}
+		}
+	}
+	return widths
+}
+
+// CompareTableCells orders two cells numerically when both are numbers, and otherwise
+// case-insensitively with runs of digits compared by value, so "file2" sorts before "file10".
+func CompareTableCells(a, b string) int {
+	if x, err := strconv.ParseFloat(strings.TrimSpace(a), 64); err == nil {
+		if y, err := strconv.ParseFloat(strings.TrimSpace(b), 64); err == nil {
+			return cmp.Compare(x, y)
+		}
+	}
+
+	ra, rb := []rune(strings.ToLower(a)), []rune(strings.ToLower(b))
+	isDigit := func(r rune) bool { return r >= '0' && r <= '9' }
+
+	i, j := 0, 0
+	for i < len(ra) && j < len(rb) {
+		if isDigit(ra[i]) && isDigit(rb[j]) {
+			startA, startB := i, j
+			for i < len(ra) && isDigit(ra[i]) {
+				i++
+			}
+			for j < len(rb) && isDigit(rb[j]) {
+				j++
+			}
+			numberA := strings.TrimLeft(string(ra[startA:i]), "0")
+			numberB := strings.TrimLeft(string(rb[startB:j]), "0")
+			if c := cmp.Compare(len(numberA), len(numberB)); c != 0 {
+				return c
+			}
+			if c := strings.Compare(numberA, numberB); c != 0 {
+				return c
+			}
+			continue
+		}
+
+		if c := cmp.Compare(ra[i], rb[j]); c != 0 {
+			return c
+		}
+		i++
+		j++
+	}
+	return cmp.Compare(len(ra)-i, len(rb)-j)
+}
+
+// SortTableRows returns the row indices ordered by the cells in column. Rows without
+// that column count as empty. Equal rows keep their original order in either direction.
+func SortTableRows(rows [][]string, column int, descending bool) []int {
+	cell := func(row int) string {
+		if column < len(rows[row]) {
+			return rows[row][column]
+		}
+		return ""
+	}
+
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		c := CompareTableCells(cell(a), cell(b))
+		if descending {
+			return -c
+		}
+		return c
+	})
+	return order
+}
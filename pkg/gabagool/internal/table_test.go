@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTableColumnWidths(t *testing.T) {
+	tests := []struct {
+		name      string
+		natural   []int32
+		fixed     []int32
+		available int32
+		overflow  bool
+		want      []int32
+	}{
+		{name: "grows to fill", natural: []int32{100, 300}, available: 800, want: []int32{200, 600}},
+		{name: "exact fit", natural: []int32{100, 300}, available: 400, want: []int32{100, 300}},
+		{name: "shrinks", natural: []int32{200, 600}, available: 400, want: []int32{100, 300}},
+		{name: "shrinks to minimum", natural: []int32{20, 780}, available: 400, want: []int32{30, 390}},
+		{name: "overflow keeps natural", natural: []int32{200, 600}, available: 400, overflow: true, want: []int32{200, 600}},
+		{name: "fixed columns keep width", natural: []int32{100, 100, 200}, fixed: []int32{0, 50}, available: 650, want: []int32{200, 50, 400}},
+		{name: "fixed columns take space first", natural: []int32{200, 100}, fixed: []int32{0, 300}, available: 400, want: []int32{100, 300}},
+		{name: "all fixed", natural: []int32{100, 100}, fixed: []int32{150, 150}, available: 200, want: []int32{150, 150}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TableColumnWidths(tt.natural, tt.fixed, tt.available, 30, tt.overflow)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TableColumnWidths = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareTableCells(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2", "10", -1},
+		{"1.5", "-3", 1},
+		{" 42 ", "42", 0},
+		{"file2", "file10", -1},
+		{"File2", "file02", 0},
+		{"apple", "Banana", -1},
+		{"abc", "ab", 1},
+		{"", "a", -1},
+		{"v1.10", "v1.9", 1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareTableCells(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareTableCells(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSortTableRows(t *testing.T) {
+	rows := [][]string{
+		{"Zelda", "1986"},
+		{"Metroid", "1986"},
+		{"Kirby"},
+		{"Mario", "1985"},
+	}
+
+	tests := []struct {
+		name       string
+		column     int
+		descending bool
+		want       []int
+	}{
+		{name: "by name", column: 0, want: []int{2, 3, 1, 0}},
+		{name: "by name descending", column: 0, descending: true, want: []int{0, 1, 3, 2}},
+		{name: "by year keeps ties stable", column: 1, want: []int{2, 3, 0, 1}},
+		{name: "by year descending keeps ties stable", column: 1, descending: true, want: []int{0, 1, 3, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SortTableRows(rows, tt.column, tt.descending); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortTableRows(%d, %v) = %v, want %v", tt.column, tt.descending, got, tt.want)
+			}
+		})
+	}
+}
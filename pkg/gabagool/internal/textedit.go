@@ -0,0 +1,33 @@
+package internal
+
+import "unicode"
+
+// PreviousWordStart returns the index of the start of the word before pos,
+// skipping any separators directly in front of it.
+func PreviousWordStart(text []rune, pos int) int {
+	i := min(max(pos, 0), len(text))
+	for i > 0 && !isWordRune(text[i-1]) {
+		i--
+	}
+	for i > 0 && isWordRune(text[i-1]) {
+		i--
+	}
+	return i
+}
+
+// NextWordEnd returns the index just past the end of the word after pos,
+// skipping any separators directly after it.
+func NextWordEnd(text []rune, pos int) int {
+	i := min(max(pos, 0), len(text))
+	for i < len(text) && !isWordRune(text[i]) {
+		i++
+	}
+	for i < len(text) && isWordRune(text[i]) {
+		i++
+	}
+	return i
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,31 @@
+package internal
+
+import "testing"
+
+func TestWordBoundaries(t *testing.T) {
+	tests := []struct {
+		text     string
+		pos      int
+		wantPrev int
+		wantNext int
+	}{
+		{text: "hello world", pos: 11, wantPrev: 6, wantNext: 11},
+		{text: "hello world", pos: 6, wantPrev: 0, wantNext: 11},
+		{text: "hello world", pos: 5, wantPrev: 0, wantNext: 11},
+		{text: "hello world", pos: 0, wantPrev: 0, wantNext: 5},
+		{text: "https://example.com/path", pos: 24, wantPrev: 20, wantNext: 24},
+		{text: "https://example.com/path", pos: 8, wantPrev: 0, wantNext: 15},
+		{text: "ゲーム 名前", pos: 6, wantPrev: 4, wantNext: 6},
+		{text: "", pos: 0, wantPrev: 0, wantNext: 0},
+	}
+
+	for _, tt := range tests {
+		text := []rune(tt.text)
+		if got := PreviousWordStart(text, tt.pos); got != tt.wantPrev {
+			t.Errorf("PreviousWordStart(%q, %d) = %d, want %d", tt.text, tt.pos, got, tt.wantPrev)
+		}
+		if got := NextWordEnd(text, tt.pos); got != tt.wantNext {
+			t.Errorf("NextWordEnd(%q, %d) = %d, want %d", tt.text, tt.pos, got, tt.wantNext)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package internal
+
+import "unicode"
+
+// TextSpan is the [Start, End) rune range of one wrapped line.
+// Line breaks are not part of any span.
+type TextSpan struct {
+	Start int
+	End   int
+}
+
+// WrapSpans splits text into lines no wider than maxWidth, breaking after spaces.
+// Unlike a plain word wrap it keeps every rune, so positions in text map back onto
+// the lines: spaces at a break stay at the end of the line they follow and are not
+// measured. A word wider than maxWidth gets a line of its own, or is split between
+// characters when breakWords is set. Every paragraph produces at least one span.
+func WrapSpans(text []rune, maxWidth int32, measure func([]rune) int32, breakWords bool) []TextSpan {
+	return WrapSpansFunc(text, maxWidth, func(start, end int) int32 {
+		return measure(text[start:end])
+	}, breakWords)
+}
+
+// WrapSpansFunc is WrapSpans with a measure that receives the [start, end) range
+// of text, for text whose width depends on more than its runes, such as mixed styles.
+func WrapSpansFunc(text []rune, maxWidth int32, measure func(start, end int) int32, breakWords bool) []TextSpan {
+	var spans []TextSpan
+
+	paragraphStart := 0
+	for i := 0; i <= len(text); i++ {
+		if i < len(text) && text[i] != '\n' {
+			continue
+		}
+		spans = append(spans, wrapParagraph(text, paragraphStart, i, maxWidth, measure, breakWords)...)
+		paragraphStart = i + 1
+	}
+
+	return spans
+}
+
+func wrapParagraph(text []rune, start, end int, maxWidth int32, measure func(start, end int) int32, breakWords bool) []TextSpan {
+	if start == end {
+		return []TextSpan{{Start: start, End: end}}
+	}
+
+	var spans []TextSpan
+	lineStart := start
+	pos := start
+
+	for pos < end {
+		wordStart := pos
+		for wordStart < end && unicode.IsSpace(text[wordStart]) {
+			wordStart++
+		}
+		wordEnd := wordStart
+		for wordEnd < end && !unicode.IsSpace(text[wordEnd]) {
+			wordEnd++
+		}
+
+		if measure(lineStart, wordEnd) <= maxWidth || wordStart == wordEnd {
+			pos = wordEnd
+			continue
+		}
+
+		if pos > lineStart {
+			// The word doesn't fit behind what is already on the line
+			spans = append(spans, TextSpan{Start: lineStart, End: wordStart})
+			lineStart, pos = wordStart, wordStart
+			continue
+		}
+
+		if !breakWords {
+			pos = wordEnd
+			continue
+		}
+
+		split := lineStart + 1
+		for split < wordEnd && measure(lineStart, split+1) <= maxWidth {
+			split++
+		}
+		spans = append(spans, TextSpan{Start: lineStart, End: split})
+		lineStart, pos = split, split
+	}
+
+	if lineStart < end || len(spans) == 0 {
+		spans = append(spans, TextSpan{Start: lineStart, End: end})
+	}
+	return spans
+}
+
+// SpanForPosition returns the index of the line holding the rune position pos.
+// A position on a wrap boundary belongs to the following line, except at the end
+// of a paragraph where there is nothing to follow.
+func SpanForPosition(spans []TextSpan, pos int) int {
+	for i, span := range spans {
+		if pos < span.End {
+			return i
+		}
+		if pos == span.End && (i == len(spans)-1 || spans[i+1].Start > pos) {
+			return i
+		}
+	}
+	return max(len(spans)-1, 0)
+}
+
+// PositionForX returns the rune position in span whose offset from the line start
+// is closest to x. The end of a wrapped line belongs to the next line, so it is never returned.
+func PositionForX(text []rune, span TextSpan, x int32, measure func([]rune) int32) int {
+	end := span.End
+	if end < len(text) && text[end] != '\n' && end > span.Start {
+		end--
+	}
+
+	best, bestDistance := span.Start, Abs32(x)
+	for pos := span.Start + 1; pos <= end; pos++ {
+		distance := Abs32(measure(text[span.Start:pos]) - x)
+		if distance > bestDistance {
+			break
+		}
+		best, bestDistance = pos, distance
+	}
+	return best
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Every rune is 10 pixels wide
+func fixedWidth(line []rune) int32 {
+	return int32(len(line)) * 10
+}
+
+func spanText(text []rune, spans []TextSpan) []string {
+	lines := make([]string, len(spans))
+	for i, s := range spans {
+		lines[i] = string(text[s.Start:s.End])
+	}
+	return lines
+}
+
+func TestWrapSpans(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		maxWidth   int32
+		breakWords bool
+		want       []string
+	}{
+		{name: "fits", text: "hello world", maxWidth: 200, want: []string{"hello world"}},
+		{name: "wraps after space", text: "hello world", maxWidth: 80, want: []string{"hello ", "world"}},
+		{name: "trailing space not measured", text: "abcd efgh", maxWidth: 40, want: []string{"abcd ", "efgh"}},
+		{name: "newlines", text: "one\n\ntwo", maxWidth: 200, want: []string{"one", "", "two"}},
+		{name: "trailing newline", text: "one\n", maxWidth: 200, want: []string{"one", ""}},
+		{name: "empty", text: "", maxWidth: 200, want: []string{""}},
+		{name: "long word kept", text: "a abcdefghij b", maxWidth: 50, want: []string{"a ", "abcdefghij ", "b"}},
+		{name: "long word split", text: "a abcdefghij b", maxWidth: 50, breakWords: true, want: []string{"a ", "abcde", "fghij ", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := []rune(tt.text)
+			got := spanText(text, WrapSpans(text, tt.maxWidth, fixedWidth, tt.breakWords))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WrapSpans(%q, %d) = %q, want %q", tt.text, tt.maxWidth, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpanForPosition(t *testing.T) {
+	text := []rune("hello world\nnext")
+	spans := WrapSpans(text, 80, fixedWidth, false) // "hello ", "world", "next"
+
+	tests := []struct {
+		pos  int
+		want int
+	}{
+		{pos: 0, want: 0},
+		{pos: 5, want: 0},
+		{pos: 6, want: 1},  // wrap boundary belongs to the next line
+		{pos: 11, want: 1}, // end of paragraph stays on its line
+		{pos: 12, want: 2},
+		{pos: 16, want: 2},
+	}
+
+	for _, tt := range tests {
+		if got := SpanForPosition(spans, tt.pos); got != tt.want {
+			t.Errorf("SpanForPosition(%d) = %d, want %d", tt.pos, got, tt.want)
+		}
+	}
+}
+
+func TestPositionForX(t *testing.T) {
+	text := []rune("hello world\nhi")
+	spans := WrapSpans(text, 80, fixedWidth, false) // "hello ", "world", "hi"
+
+	tests := []struct {
+		line int
+		x    int32
+		want int
+	}{
+		{line: 0, x: 0, want: 0},
+		{line: 0, x: 24, want: 2},
+		{line: 0, x: 500, want: 5}, // never the wrap boundary
+		{line: 1, x: 500, want: 11},
+		{line: 2, x: 14, want: 13},
+		{line: 2, x: 500, want: 14},
+	}
+
+	for _, tt := range tests {
+		if got := PositionForX(text, spans[tt.line], tt.x, fixedWidth); got != tt.want {
+			t.Errorf("PositionForX(line %d, x %d) = %d, want %d", tt.line, tt.x, got, tt.want)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package gabagool
 
 import (
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
 	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
@@ -14,6 +16,7 @@ type key struct {
 	LowerValue  string
 	UpperValue  string
 	SymbolValue string
+	Variants    []string
 	IsPressed   bool
 }
 
@@ -35,6 +38,9 @@ const (
 	KeyboardLayoutURL
 	// KeyboardLayoutNumeric is a simple numpad for entering numbers.
 	KeyboardLayoutNumeric
+	// KeyboardLayoutCustom is used with KeyboardOptions.CustomLayout.
+	// Without a custom layout it falls back to KeyboardLayoutGeneral.
+	KeyboardLayoutCustom
 )
 
 // URLShortcut represents a shortcut key on the URL keyboard.
@@ -51,6 +57,10 @@ type URLKeyboardConfig struct {
 	// 6-10 shortcuts: two row layout
 	// If empty, 10 default shortcuts are used (two rows).
 	Shortcuts []URLShortcut
+
+	// Options holds the input constraints shared with KeyboardWithOptions.
+	// Options.Layout is ignored.
+	Options KeyboardOptions
 }
 
 type virtualKeyboard struct {
@@ -66,6 +76,7 @@ type virtualKeyboard struct {
 	SpaceRect        sdl.Rect
 	ShiftRect        sdl.Rect
 	SymbolRect       sdl.Rect
+	LanguageRect     sdl.Rect
 	TextInputRect    sdl.Rect
 	KeyboardRect     sdl.Rect
 	SelectedKeyIndex int
@@ -81,6 +92,24 @@ type virtualKeyboard struct {
 	InputDelay       time.Duration
 	lastInputTime    time.Time
 	urlShortcuts     []URLShortcut
+	languages        []KeyboardLanguageLayout
+	languageIndex    int
+	accentPressKey   int
+	accentPressStart time.Time
+	accentPopup      *accentPopup
+	romajiKana       string
+	romajiPending    string
+	suggestions      *keyboardSuggestions
+	textArea         *textArea
+	customLayout     *CustomKeyboardLayout
+	history          *keyboardHistory
+	options          KeyboardOptions
+	revealing        bool
+	physicalTyping   bool
+	imeComposition   string
+	selectionAnchor  int
+	selectHeld       bool
+	selectChorded    bool
 	StatusBar        StatusBarOptions
 
 	directionalInput internal.DirectionalInput
@@ -89,6 +118,7 @@ type virtualKeyboard struct {
 var defaultKeyboardHelpLines = []string{
 	"• D-Pad: Navigate between keys",
 	"• A: Type the selected key",
+	"• Hold A: Pick an accented variant",
 	"• B: Backspace",
 	"• X: Space",
 	"• L1 / R1: Move cursor within text",
@@ -97,6 +127,10 @@ var defaultKeyboardHelpLines = []string{
 	"• Start: Enter (confirm input)",
 }
 
+var keyboardLanguageHelpLine = "• Layout key: Switch between keyboard layouts"
+
+var keyboardKanaHelpLine = "• Select (kana layout): Toggle hiragana / katakana"
+
 var numericKeyboardHelpLines = []string{
 	"• D-Pad: Navigate between keys",
 	"• A: Type the selected digit",
@@ -134,24 +168,7 @@ type keyLayout struct {
 	rows [][]interface{}
 }
 
-func createKeyLayout() *keyLayout {
-	return &keyLayout{
-		rows: [][]interface{}{
-			// Row 1: numbers + backspace
-			{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, "backspace"},
-			// Row 2: qwerty row
-			{10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
-			// Row 3: asdf row + enter
-			{20, 21, 22, 23, 24, 25, 26, 27, 28, "enter"},
-			// Row 4: shift + zxcv row + symbol
-			{"shift", 29, 30, 31, 32, 33, 34, 35, "symbol"},
-			// Row 5: space only
-			{"space"},
-		},
-	}
-}
-
-func createKeyboard(windowWidth, windowHeight int32, helpExitText string, layout KeyboardLayout) *virtualKeyboard {
+func createKeyboard(windowWidth, windowHeight int32, helpExitText string, layout KeyboardLayout, languages []KeyboardLanguageLayout) *virtualKeyboard {
 	kb := &virtualKeyboard{
 		Layout:           layout,
 		TextBuffer:       "",
@@ -166,6 +183,8 @@ func createKeyboard(windowWidth, windowHeight int32, helpExitText string, layout
 		ShowingHelp:      false,
 		InputDelay:       100 * time.Millisecond,
 		lastInputTime:    time.Now(),
+		accentPressKey:   -1,
+		selectionAnchor:  -1,
 		directionalInput: internal.NewDirectionalInputWithTiming(150*time.Millisecond, 50*time.Millisecond),
 		StatusBar:        DefaultStatusBarOptions(),
 	}
@@ -183,11 +202,21 @@ func createKeyboard(windowWidth, windowHeight int32, helpExitText string, layout
 		kb.helpOverlay = newHelpOverlay("Numeric Keyboard Help", numericKeyboardHelpLines, helpExitText)
 		setupNumericKeyboardRects(kb, windowWidth, windowHeight)
 	default:
-		kb.Keys = createKeys()
-		kb.keyLayout = createKeyLayout()
-		kb.helpOverlay = newHelpOverlay("Keyboard Help", defaultKeyboardHelpLines, helpExitText)
-		setupKeyboardRects(kb, windowWidth, windowHeight)
+		kb.languages = languages
+		kb.applyLanguage(windowWidth, windowHeight)
+		helpLines := defaultKeyboardHelpLines
+		if len(languages) > 1 {
+			helpLines = append(append([]string(nil), helpLines...), keyboardLanguageHelpLine)
+		}
+		for _, l := range languages {
+			if l.Romaji {
+				helpLines = append(append([]string(nil), helpLines...), keyboardKanaHelpLine)
+				break
+			}
+		}
+		kb.helpOverlay = newHelpOverlay("Keyboard Help", helpLines, helpExitText)
 	}
+	kb.helpOverlay.Lines = append(append([]string(nil), kb.helpOverlay.Lines...), keyboardEditingHelpLines...)
 
 	return kb
 }
@@ -207,6 +236,8 @@ func createURLKeyboard(windowWidth, windowHeight int32, helpExitText string, sho
 		ShowingHelp:      false,
 		InputDelay:       100 * time.Millisecond,
 		lastInputTime:    time.Now(),
+		accentPressKey:   -1,
+		selectionAnchor:  -1,
 		directionalInput: internal.NewDirectionalInputWithTiming(150*time.Millisecond, 50*time.Millisecond),
 		urlShortcuts:     shortcuts,
 		StatusBar:        DefaultStatusBarOptions(),
@@ -223,6 +254,7 @@ func createURLKeyboard(windowWidth, windowHeight int32, helpExitText string, sho
 		setupURLKeyboardRectsFor10(kb, windowWidth, windowHeight)
 	}
 	kb.helpOverlay = newHelpOverlay("URL Keyboard Help", urlKeyboardHelpLines, helpExitText)
+	kb.helpOverlay.Lines = append(append([]string(nil), kb.helpOverlay.Lines...), keyboardEditingHelpLines...)
 
 	return kb
 }
@@ -258,24 +290,54 @@ func populateCharKeys(keys []key, chars, symbols []string, offset int) {
 	}
 }
 
-func createKeys() []key {
-	keys := make([]key, 36)
+// createLanguageKeys builds the keys and navigation layout for a language layout.
+// The language switch key is only added when there is more than one layout to cycle.
+func createLanguageKeys(l KeyboardLanguageLayout, showLanguageKey bool) ([]key, *keyLayout) {
+	var keys []key
+	layout := &keyLayout{}
+	last := len(l.Rows) - 1
 
-	// Numbers row
-	numbers := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "0"}
-	numberSymbols := []string{"!", "@", "#", "$", "%", "^", "&", "*", "(", ")"}
-	populateCharKeys(keys, numbers, numberSymbols, 0)
+	for r, row := range l.Rows {
+		var layoutRow []interface{}
+		if r == last {
+			layoutRow = append(layoutRow, "shift")
+		}
 
-	// Letter rows with custom symbols
-	qwertySymbols := []string{"`", "~", "[", "]", "\\", "|", "{", "}", ";", ":"}
-	asdfSymbols := []string{"'", "\"", "<", ">", "?", "/", "+", "=", "_"}
-	zxcvSymbols := []string{",", ".", "-", "€", "£", "¥", "¢"}
+		for i, lower := range row.Lower {
+			k := key{
+				LowerValue:  lower,
+				UpperValue:  strings.ToUpper(lower),
+				SymbolValue: lower,
+			}
+			if i < len(row.Upper) {
+				k.UpperValue = row.Upper[i]
+			}
+			if i < len(row.Symbol) {
+				k.SymbolValue = row.Symbol[i]
+			}
+			k.Variants = l.Variants[lower]
+			layoutRow = append(layoutRow, len(keys))
+			keys = append(keys, k)
+		}
 
-	populateLetterKeys(keys, "qwertyuiop", 10, qwertySymbols)
-	populateLetterKeys(keys, "asdfghjkl", 20, asdfSymbols)
-	populateLetterKeys(keys, "zxcvbnm", 29, zxcvSymbols)
+		switch {
+		case r == 0:
+			layoutRow = append(layoutRow, "backspace")
+		case r == last-1:
+			layoutRow = append(layoutRow, "enter")
+		case r == last:
+			layoutRow = append(layoutRow, "symbol")
+		}
+		layout.rows = append(layout.rows, layoutRow)
+	}
 
-	return keys
+	if showLanguageKey {
+		layout.rows = append(layout.rows, []interface{}{"language", "space"})
+	} else {
+		layout.rows = append(layout.rows, []interface{}{"space"})
+	}
+
+	return keys, layout
 }
 
 func createURLKeyLayout() *keyLayout {
@@ -433,67 +495,128 @@ func createNumericKeys() []key {
 	return keys
 }
 
-func setupKeyboardRects(kb *virtualKeyboard, windowWidth, windowHeight int32) {
+func setupLanguageKeyboardRects(kb *virtualKeyboard, windowWidth, windowHeight int32) {
 	dims := internal.CalculateKeyboardDimensions(windowWidth, windowHeight)
 	kb.KeyboardRect = dims.KeyboardRect()
 	kb.TextInputRect = dims.TextInputRect()
 
 	sizes := internal.CalculateKeySizes(dims, 6)
-	keyWidth := sizes.KeyWidth
-	keyHeight := sizes.KeyHeight
-	keySpacing := sizes.KeySpacing
 
-	// Calculate row widths for centering
-	row1Width := internal.CalculateRowWidth(10, keyWidth, keySpacing, 0, sizes.BackspaceWidth)
-	row2Width := internal.CalculateRowWidth(10, keyWidth, keySpacing, 0, 0)
-	row3Width := internal.CalculateRowWidth(9, keyWidth, keySpacing, 0, sizes.EnterWidth)
-	row4Width := internal.CalculateRowWidth(7, keyWidth, keySpacing, sizes.ShiftWidth, sizes.SymbolWidth)
-	row5Width := sizes.SpaceWidth
+	// Layouts with long rows (Cyrillic, Nordic) shrink the keys until the widest row fits
+	if widest := kb.widestLayoutRow(sizes); widest > dims.KeyboardWidth {
+		scaled := dims
+		scaled.KeyboardWidth = dims.KeyboardWidth * dims.KeyboardWidth / widest
+		sizes = internal.CalculateKeySizes(scaled, 6)
+	}
 
-	maxRowWidth := internal.MaxRowWidth(row1Width, row2Width, row3Width, row4Width, row5Width)
-	leftMargin := dims.StartX + (dims.KeyboardWidth-maxRowWidth)/2
-	y := dims.KeyboardStartY + keySpacing
+	rowWidths := make([]int32, len(kb.keyLayout.rows))
+	for r, row := range kb.keyLayout.rows {
+		rowWidths[r] = kb.layoutRowWidth(row, sizes)
+	}
 
-	// Row 1: Numbers + Backspace
-	x := leftMargin
-	for i := 0; i < 10; i++ {
-		kb.Keys[i].Rect = sdl.Rect{X: x, Y: y, W: keyWidth, H: keyHeight}
-		x += keyWidth + keySpacing
+	maxRowWidth := internal.MaxRowWidth(rowWidths...)
+	leftMargin := dims.StartX + (dims.KeyboardWidth-maxRowWidth)/2
+	y := dims.KeyboardStartY + sizes.KeySpacing
+
+	kb.LanguageRect = sdl.Rect{}
+	for r, row := range kb.keyLayout.rows {
+		x := leftMargin + (maxRowWidth-rowWidths[r])/2
+		for _, item := range row {
+			width := layoutItemWidth(item, sizes)
+			rect := sdl.Rect{X: x, Y: y, W: width, H: sizes.KeyHeight}
+			switch v := item.(type) {
+			case int:
+				kb.Keys[v].Rect = rect
+			case string:
+				if target := kb.specialKeyRect(v); target != nil {
+					*target = rect
+				}
+			}
+			x += width + sizes.KeySpacing
+		}
+		y += sizes.KeyHeight + sizes.KeySpacing
 	}
-	kb.BackspaceRect = sdl.Rect{X: x, Y: y, W: sizes.BackspaceWidth, H: keyHeight}
+}
 
-	// Row 2: QWERTY
-	y += keyHeight + keySpacing
-	x = leftMargin + (maxRowWidth-row2Width)/2
-	for i := 10; i < 20; i++ {
-		kb.Keys[i].Rect = sdl.Rect{X: x, Y: y, W: keyWidth, H: keyHeight}
-		x += keyWidth + keySpacing
+func (kb *virtualKeyboard) widestLayoutRow(sizes internal.KeySizes) int32 {
+	widths := make([]int32, len(kb.keyLayout.rows))
+	for r, row := range kb.keyLayout.rows {
+		widths[r] = kb.layoutRowWidth(row, sizes)
 	}
+	return internal.MaxRowWidth(widths...)
+}
 
-	// Row 3: ASDF + Enter
-	y += keyHeight + keySpacing
-	x = leftMargin + (maxRowWidth-row3Width)/2
-	for i := 20; i < 29; i++ {
-		kb.Keys[i].Rect = sdl.Rect{X: x, Y: y, W: keyWidth, H: keyHeight}
-		x += keyWidth + keySpacing
+func (kb *virtualKeyboard) layoutRowWidth(row []interface{}, sizes internal.KeySizes) int32 {
+	width := int32(0)
+	for i, item := range row {
+		if i > 0 {
+			width += sizes.KeySpacing
+		}
+		width += layoutItemWidth(item, sizes)
+	}
+	return width
+}
+
+func layoutItemWidth(item interface{}, sizes internal.KeySizes) int32 {
+	switch item {
+	case "backspace":
+		return sizes.BackspaceWidth
+	case "enter":
+		return sizes.EnterWidth
+	case "shift":
+		return sizes.ShiftWidth
+	case "symbol":
+		return sizes.SymbolWidth
+	case "space":
+		return sizes.SpaceWidth
+	case "language":
+		return sizes.ShortcutWidth
+	}
+	return sizes.KeyWidth
+}
+
+func (kb *virtualKeyboard) specialKeyRect(name string) *sdl.Rect {
+	switch name {
+	case "backspace":
+		return &kb.BackspaceRect
+	case "enter":
+		return &kb.EnterRect
+	case "space":
+		return &kb.SpaceRect
+	case "shift":
+		return &kb.ShiftRect
+	case "symbol":
+		return &kb.SymbolRect
+	case "language":
+		return &kb.LanguageRect
+	}
+	return nil
+}
+
+// squeezeKeys scales the key rects vertically so the keyboard starts at top,
+// freeing the space above it for other elements.
+func (kb *virtualKeyboard) squeezeKeys(top int32) {
+	area := kb.KeyboardRect
+	available := area.Y + area.H - top
+
+	squeeze := func(r *sdl.Rect) {
+		if r.W == 0 {
+			return
+		}
+		rowTop := r.Y - area.Y
+		rowBottom := rowTop + r.H
+		r.Y = top + rowTop*available/area.H
+		r.H = top + rowBottom*available/area.H - r.Y
 	}
-	kb.EnterRect = sdl.Rect{X: x, Y: y, W: sizes.EnterWidth, H: keyHeight}
 
-	// Row 4: Shift + ZXCV + Symbol
-	y += keyHeight + keySpacing
-	x = leftMargin + (maxRowWidth-row4Width)/2
-	kb.ShiftRect = sdl.Rect{X: x, Y: y, W: sizes.ShiftWidth, H: keyHeight}
-	x += sizes.ShiftWidth + keySpacing
-	for i := 29; i < 36; i++ {
-		kb.Keys[i].Rect = sdl.Rect{X: x, Y: y, W: keyWidth, H: keyHeight}
-		x += keyWidth + keySpacing
+	for i := range kb.Keys {
+		squeeze(&kb.Keys[i].Rect)
+	}
+	for _, name := range []string{"backspace", "enter", "space", "shift", "symbol", "language"} {
+		squeeze(kb.specialKeyRect(name))
 	}
-	kb.SymbolRect = sdl.Rect{X: x, Y: y, W: sizes.SymbolWidth, H: keyHeight}
 
-	// Row 5: Space
-	y += keyHeight + keySpacing
-	x = leftMargin + (maxRowWidth-row5Width)/2
-	kb.SpaceRect = sdl.Rect{X: x, Y: y, W: sizes.SpaceWidth, H: keyHeight}
+	kb.KeyboardRect = sdl.Rect{X: area.X, Y: top, W: area.W, H: available}
 }
 
 func setupURLKeyboardRects(kb *virtualKeyboard, windowWidth, windowHeight int32) {
@@ -711,6 +834,8 @@ type KeyboardResult struct {
 // Keyboard displays a virtual keyboard for text input.
 // An optional layout parameter can be provided to use a specific keyboard layout.
 // If no layout is specified, KeyboardLayoutGeneral is used.
+// The general layout uses the languages set with SetDefaultKeyboardLanguages,
+// or the layout matching the active i18n locale.
 // Returns ErrCancelled if the user exits without pressing Enter.
 func Keyboard(initialText string, helpExitText string, layout ...KeyboardLayout) (*KeyboardResult, error) {
 	selectedLayout := KeyboardLayoutGeneral
@@ -718,28 +843,74 @@ func Keyboard(initialText string, helpExitText string, layout ...KeyboardLayout)
 		selectedLayout = layout[0]
 	}
 
+	return KeyboardWithOptions(initialText, helpExitText, KeyboardOptions{Layout: selectedLayout})
+}
+
+// KeyboardWithLanguages displays the general keyboard with the given language layouts enabled.
+// The first language is shown initially and the layout key cycles through the rest.
+// Returns ErrCancelled if the user exits without pressing Enter.
+func KeyboardWithLanguages(initialText string, helpExitText string, languages ...KeyboardLanguage) (*KeyboardResult, error) {
+	return KeyboardWithOptions(initialText, helpExitText, KeyboardOptions{Languages: languages})
+}
+
+// KeyboardWithSuggestions displays the general keyboard with a suggestion strip above the keys.
+// The provider is queried with the text before the cursor whenever it changes;
+// L2 highlights the next suggestion and R2 accepts it.
+// Returns ErrCancelled if the user exits without pressing Enter.
+func KeyboardWithSuggestions(initialText string, helpExitText string, provider SuggestionProvider) (*KeyboardResult, error) {
+	return KeyboardWithOptions(initialText, helpExitText, KeyboardOptions{Suggestions: provider})
+}
+
+// KeyboardWithOptions displays a virtual keyboard configured by options.
+// Returns ErrCancelled if the user exits without pressing Enter.
+func KeyboardWithOptions(initialText string, helpExitText string, options KeyboardOptions) (*KeyboardResult, error) {
 	window := internal.GetWindow()
-	renderer := window.Renderer
+
+	var kb *virtualKeyboard
+	if options.CustomLayout != nil {
+		if err := options.CustomLayout.validate(); err != nil {
+			return nil, err
+		}
+		kb = createCustomKeyboard(window.GetWidth(), window.GetHeight(), helpExitText, options.CustomLayout)
+	} else {
+		layout := options.Layout
+		if layout == KeyboardLayoutCustom {
+			layout = KeyboardLayoutGeneral
+		}
+		kb = createKeyboard(window.GetWidth(), window.GetHeight(), helpExitText, layout, resolveKeyboardLanguages(options.Languages))
+	}
+	kb.applyOptions(options)
+	return runKeyboard(kb, initialText)
+}
+
+func runKeyboard(kb *virtualKeyboard, initialText string) (*KeyboardResult, error) {
+	renderer := internal.GetWindow().Renderer
 	font := internal.Fonts.MediumFont
 
-	kb := createKeyboard(window.GetWidth(), window.GetHeight(), helpExitText, selectedLayout)
 	if initialText != "" {
 		kb.TextBuffer = initialText
-		kb.CursorPosition = len(initialText)
+		kb.CursorPosition = utf8.RuneCountInString(initialText)
 	}
 
+	kb.startPhysicalInput()
+	defer kb.stopPhysicalInput()
+
 	for {
 		if kb.handleEvents() {
 			break
 		}
 
 		kb.handleDirectionalRepeats()
+		kb.updateAccentPress()
+		kb.updateSuggestions()
 
 		kb.updateCursorBlink()
 		kb.render(renderer, font)
 	}
 
 	if kb.EnterPressed {
+		kb.commitComposition()
+		kb.recordHistory(kb.TextBuffer)
 		return &KeyboardResult{Text: kb.TextBuffer}, nil
 	}
 	return nil, ErrCancelled
@@ -766,30 +937,11 @@ func URLKeyboard(initialText string, helpExitText string, config ...URLKeyboardC
 	}
 
 	window := internal.GetWindow()
-	renderer := window.Renderer
-	font := internal.Fonts.MediumFont
-
 	kb := createURLKeyboard(window.GetWidth(), window.GetHeight(), helpExitText, shortcuts)
-	if initialText != "" {
-		kb.TextBuffer = initialText
-		kb.CursorPosition = len(initialText)
+	if len(config) > 0 {
+		kb.applyOptions(config[0].Options)
 	}
-
-	for {
-		if kb.handleEvents() {
-			break
-		}
-
-		kb.handleDirectionalRepeats()
-
-		kb.updateCursorBlink()
-		kb.render(renderer, font)
-	}
-
-	if kb.EnterPressed {
-		return &KeyboardResult{Text: kb.TextBuffer}, nil
-	}
-	return nil, ErrCancelled
+	return runKeyboard(kb, initialText)
 }
 
 func (kb *virtualKeyboard) handleEvents() bool {
@@ -803,12 +955,32 @@ func (kb *virtualKeyboard) handleEvents() bool {
 		case *sdl.QuitEvent:
 			return true
 
+		case *sdl.TextInputEvent:
+			kb.handleTextInputEvent(event.(*sdl.TextInputEvent))
+
+		case *sdl.TextEditingEvent:
+			kb.handleTextEditingEvent(event.(*sdl.TextEditingEvent))
+
 		case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.ControllerAxisEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent:
+			if keyEvent, ok := event.(*sdl.KeyboardEvent); ok {
+				if handled, exit := kb.handlePhysicalKeyEvent(keyEvent); handled {
+					if exit {
+						return true
+					}
+					continue
+				}
+			}
+
 			inputEvent := processor.ProcessSDLEvent(event.(sdl.Event))
 			if inputEvent == nil {
 				continue
 			}
 
+			// Going back to the controller hands the keyboard back to the on-screen layout
+			if inputEvent.Pressed && inputEvent.Source != internal.SourceKeyboard {
+				kb.physicalTyping = false
+			}
+
 			if inputEvent.Pressed {
 				if kb.handleInputEvent(inputEvent) {
 					return true
@@ -843,6 +1015,16 @@ func (kb *virtualKeyboard) handleInputEvent(inputEvent *internal.Event) bool {
 		return kb.handleHelpInputEvent(button)
 	}
 
+	if kb.accentPopup != nil {
+		return kb.handleAccentPopupInput(button)
+	}
+
+	// Select doubles as a modifier for the editing shortcuts
+	if kb.selectHeld && kb.handleSelectChord(button) {
+		kb.selectChorded = true
+		return false
+	}
+
 	// Handle keyboard input
 	switch button {
 	case constants.VirtualButtonUp, constants.VirtualButtonDown,
@@ -851,6 +1033,9 @@ func (kb *virtualKeyboard) handleInputEvent(inputEvent *internal.Event) bool {
 		kb.navigate(button)
 		return false
 	case constants.VirtualButtonA:
+		if kb.startAccentPress() {
+			return false
+		}
 		kb.processSelection()
 		return kb.EnterPressed
 	case constants.VirtualButtonB:
@@ -861,13 +1046,19 @@ func (kb *virtualKeyboard) handleInputEvent(inputEvent *internal.Event) bool {
 			kb.insertSpace()
 		} else if kb.Layout == KeyboardLayoutURL {
 			kb.toggleSymbols()
+		} else if kb.Layout == KeyboardLayoutCustom {
+			// Custom layouts get whichever of the two they have a key for
+			if kb.SpaceRect.W > 0 {
+				kb.insertSpace()
+			} else if kb.SymbolRect.W > 0 {
+				kb.toggleSymbols()
+			}
 		}
 		return false
 	case constants.VirtualButtonSelect:
-		// No shift in numeric layout
-		if kb.Layout != KeyboardLayoutNumeric {
-			kb.toggleShift()
-		}
+		// Shift is applied on release, unless Select was used for an editing shortcut
+		kb.selectHeld = true
+		kb.selectChorded = false
 		return false
 	case constants.VirtualButtonY:
 		return true // Exit without saving
@@ -880,6 +1071,16 @@ func (kb *virtualKeyboard) handleInputEvent(inputEvent *internal.Event) bool {
 	case constants.VirtualButtonR1:
 		kb.moveCursor(1)
 		return false
+	case constants.VirtualButtonL2:
+		kb.cycleSuggestion()
+		return false
+	case constants.VirtualButtonR2:
+		if kb.options.Masked {
+			kb.revealing = true
+		} else {
+			kb.acceptSuggestion()
+		}
+		return false
 	}
 
 	return false
@@ -906,6 +1107,19 @@ func (kb *virtualKeyboard) handleHelpInputEvent(button constants.VirtualButton)
 
 func (kb *virtualKeyboard) handleInputEventRelease(inputEvent *internal.Event) {
 	kb.directionalInput.SetHeld(inputEvent.Button, false)
+
+	switch inputEvent.Button {
+	case constants.VirtualButtonA:
+		kb.finishAccentPress()
+	case constants.VirtualButtonR2:
+		kb.revealing = false
+	case constants.VirtualButtonSelect:
+		// No shift in numeric layout
+		if kb.selectHeld && !kb.selectChorded && kb.Layout != KeyboardLayoutNumeric {
+			kb.toggleShift()
+		}
+		kb.selectHeld = false
+	}
 }
 
 func (kb *virtualKeyboard) handleDirectionalRepeats() {
@@ -915,6 +1129,11 @@ func (kb *virtualKeyboard) handleDirectionalRepeats() {
 }
 
 func (kb *virtualKeyboard) navigate(button constants.VirtualButton) {
+	if kb.accentPopup != nil {
+		kb.moveAccentSelection(button)
+		return
+	}
+
 	layout := kb.keyLayout
 	currentRow, currentCol := kb.findCurrentPosition(layout)
 
@@ -934,7 +1153,7 @@ func (kb *virtualKeyboard) navigate(button constants.VirtualButton) {
 }
 
 func (kb *virtualKeyboard) findCurrentPosition(layout *keyLayout) (int, int) {
-	specialKeys := map[int]string{1: "backspace", 2: "enter", 3: "space", 4: "shift", 5: "symbol"}
+	specialKeys := map[int]string{1: "backspace", 2: "enter", 3: "space", 4: "shift", 5: "symbol", 6: "language"}
 
 	if kb.SelectedSpecial > 0 {
 		targetKey := specialKeys[kb.SelectedSpecial]
@@ -1006,7 +1225,7 @@ func (kb *virtualKeyboard) setSelection(layout *keyLayout, row, col int) {
 		kb.Keys[kb.SelectedKeyIndex].IsPressed = true
 	} else if str, ok := selectedKey.(string); ok {
 		kb.SelectedKeyIndex = -1
-		specialMap := map[string]int{"backspace": 1, "enter": 2, "space": 3, "shift": 4, "symbol": 5}
+		specialMap := map[string]int{"backspace": 1, "enter": 2, "space": 3, "shift": 4, "symbol": 5, "language": 6}
 		kb.SelectedSpecial = specialMap[str]
 	}
 }
@@ -1014,7 +1233,9 @@ func (kb *virtualKeyboard) setSelection(layout *keyLayout, row, col int) {
 func (kb *virtualKeyboard) processSelection() {
 	if kb.SelectedKeyIndex >= 0 && kb.SelectedKeyIndex < len(kb.Keys) {
 		keyValue := kb.getKeyValue(kb.SelectedKeyIndex)
-		kb.insertText(keyValue)
+		if kb.isTextAllowed(keyValue) {
+			kb.typeText(keyValue)
+		}
 	} else {
 		kb.handleSpecialKey()
 	}
@@ -1027,8 +1248,6 @@ func (kb *virtualKeyboard) getKeyValue(index int) string {
 	key := kb.Keys[index]
 	if kb.CurrentState == symbolsMode {
 		return key.SymbolValue
-	} else if kb.Layout == KeyboardLayoutGeneral && index < 10 && kb.ShiftPressed {
-		return key.SymbolValue
 	} else if kb.CurrentState == upperCase {
 		return key.UpperValue
 	}
@@ -1036,7 +1255,15 @@ func (kb *virtualKeyboard) getKeyValue(index int) string {
 }
 
 func (kb *virtualKeyboard) insertText(text string) {
-	if kb.CursorPosition == len(kb.TextBuffer) {
+	kb.commitComposition()
+	kb.deleteSelection()
+
+	text = kb.constrainText(text)
+	if text == "" {
+		return
+	}
+
+	if kb.CursorPosition == utf8.RuneCountInString(kb.TextBuffer) {
 		kb.TextBuffer += text
 	} else {
 		textRunes := []rune(kb.TextBuffer)
@@ -1052,17 +1279,27 @@ func (kb *virtualKeyboard) handleSpecialKey() {
 	case 1: // backspace
 		kb.backspace()
 	case 2: // enter
-		kb.EnterPressed = true
+		if kb.textArea != nil {
+			kb.insertText("\n")
+		} else {
+			kb.EnterPressed = true
+		}
 	case 3: // space
 		kb.insertSpace()
 	case 4: // shift
 		kb.toggleShift()
 	case 5: // symbol
 		kb.toggleSymbols()
+	case 6: // language
+		kb.cycleLanguage()
 	}
 }
 
 func (kb *virtualKeyboard) backspace() {
+	if kb.backspaceComposition() || kb.deleteSelection() {
+		return
+	}
+
 	if kb.CursorPosition > 0 {
 		textRunes := []rune(kb.TextBuffer)
 		before := string(textRunes[:kb.CursorPosition-1])
@@ -1089,6 +1326,8 @@ func (kb *virtualKeyboard) toggleShift() {
 			kb.CurrentState = lowerCase
 		}
 	}
+
+	kb.convertCompositionScript()
 }
 
 func (kb *virtualKeyboard) toggleSymbols() {
@@ -1104,8 +1343,30 @@ func (kb *virtualKeyboard) toggleSymbols() {
 	}
 }
 
+// applyLanguage rebuilds the keys for the current language and lays them out again.
+func (kb *virtualKeyboard) applyLanguage(windowWidth, windowHeight int32) {
+	kb.Keys, kb.keyLayout = createLanguageKeys(kb.languages[kb.languageIndex], len(kb.languages) > 1)
+	setupLanguageKeyboardRects(kb, windowWidth, windowHeight)
+	kb.reserveTextArea()
+	kb.reserveSuggestionStrip()
+}
+
+func (kb *virtualKeyboard) cycleLanguage() {
+	if len(kb.languages) < 2 {
+		return
+	}
+
+	kb.commitComposition()
+	kb.languageIndex = (kb.languageIndex + 1) % len(kb.languages)
+	window := internal.GetWindow()
+	kb.applyLanguage(window.GetWidth(), window.GetHeight())
+}
+
 func (kb *virtualKeyboard) moveCursor(direction int) {
-	if direction > 0 && kb.CursorPosition < len(kb.TextBuffer) {
+	kb.commitComposition()
+	kb.selectionAnchor = -1
+
+	if direction > 0 && kb.CursorPosition < utf8.RuneCountInString(kb.TextBuffer) {
 		kb.CursorPosition++
 	} else if direction < 0 && kb.CursorPosition > 0 {
 		kb.CursorPosition--
@@ -1115,6 +1376,28 @@ func (kb *virtualKeyboard) moveCursor(direction int) {
 	kb.LastCursorBlink = time.Now()
 }
 
+func (kb *virtualKeyboard) moveCursorTo(position int) {
+	kb.commitComposition()
+	kb.selectionAnchor = -1
+
+	kb.CursorPosition = max(0, min(position, utf8.RuneCountInString(kb.TextBuffer)))
+	kb.CursorVisible = true
+	kb.LastCursorBlink = time.Now()
+}
+
+// deleteForward removes the character after the cursor.
+func (kb *virtualKeyboard) deleteForward() {
+	kb.commitComposition()
+	if kb.deleteSelection() {
+		return
+	}
+
+	textRunes := []rune(kb.TextBuffer)
+	if kb.CursorPosition < len(textRunes) {
+		kb.TextBuffer = string(textRunes[:kb.CursorPosition]) + string(textRunes[kb.CursorPosition+1:])
+	}
+}
+
 func (kb *virtualKeyboard) updateCursorBlink() {
 	if time.Since(kb.LastCursorBlink) > kb.CursorBlinkRate {
 		kb.CursorVisible = !kb.CursorVisible
@@ -1156,6 +1439,10 @@ func (kb *virtualKeyboard) render(renderer *sdl.Renderer, font *ttf.Font) {
 		kb.renderTextInput(renderer, font)
 		kb.renderKeys(renderer, font)
 		kb.renderSpecialKeys(renderer)
+		kb.renderSuggestions(renderer)
+		if kb.accentPopup != nil {
+			kb.renderAccentPopup(renderer, font)
+		}
 		renderStatusBar(renderer, internal.Fonts.SmallFont, kb.StatusBar, internal.UniformPadding(20))
 		kb.renderFooter(renderer)
 	}
@@ -1168,22 +1455,36 @@ func (kb *virtualKeyboard) render(renderer *sdl.Renderer, font *ttf.Font) {
 }
 
 func (kb *virtualKeyboard) renderTextInput(renderer *sdl.Renderer, font *ttf.Font) {
+	if kb.textArea != nil {
+		kb.renderTextArea(renderer)
+		return
+	}
+
 	renderer.SetDrawColor(50, 50, 50, 255)
 	renderer.FillRect(&kb.TextInputRect)
 	renderer.SetDrawColor(200, 200, 200, 255)
 	renderer.DrawRect(&kb.TextInputRect)
 
 	padding := int32(10)
-	if kb.TextBuffer != "" {
+	if kb.TextBuffer != "" || kb.compositionText() != "" {
 		kb.renderTextWithCursor(renderer, font, padding)
-	} else if kb.CursorVisible {
+		return
+	}
+
+	if kb.options.Placeholder != "" {
+		kb.renderPlaceholder(renderer, font, padding)
+	}
+	if kb.CursorVisible {
 		kb.renderEmptyCursor(renderer, font, padding)
 	}
 }
 
 func (kb *virtualKeyboard) renderTextWithCursor(renderer *sdl.Renderer, font *ttf.Font, padding int32) {
+	text, compositionStart, compositionEnd := kb.displayText()
+	text = kb.maskText(text)
+
 	textColor := sdl.Color{R: 255, G: 255, B: 255, A: 255}
-	textSurface, err := font.RenderUTF8Blended(kb.TextBuffer, textColor)
+	textSurface, err := font.RenderUTF8Blended(text, textColor)
 	if err != nil {
 		return
 	}
@@ -1195,8 +1496,8 @@ func (kb *virtualKeyboard) renderTextWithCursor(renderer *sdl.Renderer, font *tt
 	}
 	defer textTexture.Destroy()
 
-	// Calculate cursor position and scrolling
-	cursorX := kb.calculateCursorX(font)
+	// Calculate cursor position and scrolling; the cursor sits after any composition
+	cursorX := kb.calculateCursorX(font, text, compositionEnd)
 	visibleWidth := kb.TextInputRect.W - (padding * 2)
 	offsetX := kb.calculateScrollOffset(cursorX, visibleWidth, textSurface.W, padding)
 
@@ -1212,8 +1513,36 @@ func (kb *virtualKeyboard) renderTextWithCursor(renderer *sdl.Renderer, font *tt
 		W: srcRect.W,
 		H: textSurface.H,
 	}
+
+	// Highlight the selection behind the text
+	if start, end, ok := kb.selectionRange(); ok && compositionEnd == compositionStart {
+		startX := internal.Max32(kb.calculateCursorX(font, text, start)-offsetX, 0)
+		endX := internal.Min32(kb.calculateCursorX(font, text, end)-offsetX, visibleWidth)
+		if endX > startX {
+			highlight := sdl.Rect{X: kb.TextInputRect.X + padding + startX, Y: textRect.Y, W: endX - startX, H: textSurface.H}
+			renderer.SetDrawColor(100, 100, 240, 255)
+			renderer.FillRect(&highlight)
+		}
+	}
+
 	renderer.Copy(textTexture, srcRect, &textRect)
 
+	// Underline the in-progress composition
+	if compositionEnd > compositionStart {
+		startX := internal.Max32(kb.calculateCursorX(font, text, compositionStart)-offsetX, 0)
+		endX := internal.Min32(cursorX-offsetX, visibleWidth)
+		if endX > startX {
+			underline := sdl.Rect{
+				X: kb.TextInputRect.X + padding + startX,
+				Y: textRect.Y + textSurface.H - 2,
+				W: endX - startX,
+				H: 2,
+			}
+			renderer.SetDrawColor(255, 255, 255, 255)
+			renderer.FillRect(&underline)
+		}
+	}
+
 	// Render cursor
 	if kb.CursorVisible {
 		cursorRect := sdl.Rect{
@@ -1242,12 +1571,13 @@ func (kb *virtualKeyboard) renderEmptyCursor(renderer *sdl.Renderer, font *ttf.F
 	renderer.FillRect(&cursorRect)
 }
 
-func (kb *virtualKeyboard) calculateCursorX(font *ttf.Font) int32 {
-	if kb.CursorPosition == 0 {
+// calculateCursorX returns the rendered width of the first position runes of text.
+func (kb *virtualKeyboard) calculateCursorX(font *ttf.Font, text string, position int) int32 {
+	if position == 0 {
 		return 0
 	}
 
-	cursorText := kb.TextBuffer[:kb.CursorPosition]
+	cursorText := string([]rune(text)[:position])
 	textColor := sdl.Color{R: 255, G: 255, B: 255, A: 255}
 	cursorSurface, err := font.RenderUTF8Blended(cursorText, textColor)
 	if err != nil {
@@ -1282,7 +1612,15 @@ func (kb *virtualKeyboard) renderKeys(renderer *sdl.Renderer, font *ttf.Font) {
 }
 
 func (kb *virtualKeyboard) renderSingleKey(renderer *sdl.Renderer, font *ttf.Font, index int, key key) {
+	keyValue := kb.getKeyValue(index)
+	allowed := kb.isTextAllowed(keyValue)
+
 	bgColor := sdl.Color{R: 50, G: 50, B: 60, A: 255}
+	textColor := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	if !allowed {
+		bgColor = sdl.Color{R: 35, G: 35, B: 40, A: 255}
+		textColor = sdl.Color{R: 100, G: 100, B: 110, A: 255}
+	}
 	if index == kb.SelectedKeyIndex {
 		bgColor = sdl.Color{R: 100, G: 100, B: 240, A: 255}
 	} else if key.IsPressed {
@@ -1294,12 +1632,18 @@ func (kb *virtualKeyboard) renderSingleKey(renderer *sdl.Renderer, font *ttf.Fon
 	renderer.SetDrawColor(70, 70, 80, 255)
 	renderer.DrawRect(&key.Rect)
 
-	keyValue := kb.getKeyValue(index)
-	kb.renderKeyText(renderer, font, keyValue, key.Rect)
+	kb.renderKeyTextWithColor(renderer, font, keyValue, key.Rect, textColor)
+
+	if len(key.Variants) > 0 && kb.CurrentState != symbolsMode {
+		kb.renderAccentMarker(renderer, key.Rect)
+	}
 }
 
 func (kb *virtualKeyboard) renderKeyText(renderer *sdl.Renderer, font *ttf.Font, text string, rect sdl.Rect) {
-	textColor := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	kb.renderKeyTextWithColor(renderer, font, text, rect, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+}
+
+func (kb *virtualKeyboard) renderKeyTextWithColor(renderer *sdl.Renderer, font *ttf.Font, text string, rect sdl.Rect, textColor sdl.Color) {
 	textSurface, err := font.RenderUTF8Blended(text, textColor)
 	if err != nil {
 		return
@@ -1337,9 +1681,26 @@ func (kb *virtualKeyboard) renderSpecialKeys(renderer *sdl.Renderer) {
 	if kb.Layout != KeyboardLayoutURL {
 		kb.renderSpaceKey(renderer)
 	}
+
+	if kb.LanguageRect.W > 0 {
+		label := kb.languages[kb.languageIndex].Label
+		if kb.languages[kb.languageIndex].Romaji && kb.ShiftPressed {
+			label = internal.ToKatakana(label)
+		}
+		kb.renderSpecialKeyWithFont(renderer, kb.LanguageRect, label, internal.Fonts.SmallFont, kb.SelectedSpecial == 6)
+	}
 }
 
 func (kb *virtualKeyboard) renderSpecialKey(renderer *sdl.Renderer, rect sdl.Rect, symbol string, isSelected bool) {
+	kb.renderSpecialKeyWithFont(renderer, rect, symbol, internal.Fonts.LargeFont, isSelected)
+}
+
+func (kb *virtualKeyboard) renderSpecialKeyWithFont(renderer *sdl.Renderer, rect sdl.Rect, symbol string, font *ttf.Font, isSelected bool) {
+	// Layouts without this key leave its rect empty
+	if rect.W == 0 {
+		return
+	}
+
 	bgColor := sdl.Color{R: 50, G: 50, B: 60, A: 255}
 	if isSelected {
 		bgColor = sdl.Color{R: 100, G: 100, B: 240, A: 255}
@@ -1351,7 +1712,7 @@ func (kb *virtualKeyboard) renderSpecialKey(renderer *sdl.Renderer, rect sdl.Rec
 	renderer.DrawRect(&rect)
 
 	textColor := sdl.Color{R: 255, G: 255, B: 255, A: 255}
-	textSurface, err := internal.Fonts.LargeFont.RenderUTF8Blended(symbol, textColor)
+	textSurface, err := font.RenderUTF8Blended(symbol, textColor)
 	if err != nil {
 		return
 	}
@@ -1373,7 +1734,14 @@ func (kb *virtualKeyboard) renderSpecialKey(renderer *sdl.Renderer, rect sdl.Rec
 }
 
 func (kb *virtualKeyboard) renderSpaceKey(renderer *sdl.Renderer) {
+	if kb.SpaceRect.W == 0 {
+		return
+	}
+
 	bgColor := sdl.Color{R: 50, G: 50, B: 60, A: 255}
+	if !kb.isTextAllowed(" ") {
+		bgColor = sdl.Color{R: 35, G: 35, B: 40, A: 255}
+	}
 	if kb.SelectedSpecial == 3 {
 		bgColor = sdl.Color{R: 100, G: 100, B: 240, A: 255}
 	}
@@ -1397,12 +1765,23 @@ func (kb *virtualKeyboard) renderSpaceKey(renderer *sdl.Renderer) {
 }
 
 func (kb *virtualKeyboard) renderFooter(renderer *sdl.Renderer) {
+	items := []FooterHelpItem{
+		{ButtonName: "Menu", HelpText: "Help"},
+	}
+	if kb.options.Masked {
+		items = append(items, FooterHelpItem{ButtonName: "R2", HelpText: "Hold to Reveal"})
+	}
+	if kb.physicalTyping {
+		items = append(items, FooterHelpItem{ButtonName: "Esc", HelpText: "On-screen Keys"})
+	}
+	if kb.textArea != nil {
+		items = append(items, FooterHelpItem{ButtonName: "Start", HelpText: "Save"})
+	}
+
 	renderFooter(
 		renderer,
 		internal.Fonts.SmallFont,
-		[]FooterHelpItem{
-			{ButtonName: "Menu", HelpText: "Help"},
-		},
+		items,
 		20,
 		true,
 		true,
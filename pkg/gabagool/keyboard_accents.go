@@ -0,0 +1,199 @@
+package gabagool
+
+import (
+	"strings"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// accentLongPressDelay is how long A has to be held on a key before its variants pop up.
+const accentLongPressDelay = 400 * time.Millisecond
+
+var latinAccentVariants = map[string][]string{
+	"a": {"à", "á", "â", "ä", "æ", "ã", "å", "ā"},
+	"c": {"ç", "ć", "č"},
+	"d": {"ð", "ď"},
+	"e": {"é", "è", "ê", "ë", "ē", "ę", "ė"},
+	"g": {"ğ"},
+	"i": {"í", "ì", "î", "ï", "ī"},
+	"l": {"ł"},
+	"n": {"ñ", "ń", "ň"},
+	"o": {"ó", "ò", "ô", "ö", "õ", "ø", "ō", "œ"},
+	"r": {"ř"},
+	"s": {"ß", "ś", "š"},
+	"t": {"þ", "ť"},
+	"u": {"ú", "ù", "û", "ü", "ū", "ů"},
+	"y": {"ý", "ÿ"},
+	"z": {"ž", "ź", "ż"},
+}
+
+var cyrillicAccentVariants = map[string][]string{
+	"е": {"ё", "є"},
+	"и": {"й", "і", "ї"},
+	"г": {"ґ"},
+	"у": {"ў"},
+	"ь": {"ъ"},
+}
+
+var greekAccentVariants = map[string][]string{
+	"α": {"ά"},
+	"ε": {"έ"},
+	"η": {"ή"},
+	"ι": {"ί", "ϊ", "ΐ"},
+	"ο": {"ό"},
+	"υ": {"ύ", "ϋ", "ΰ"},
+	"ω": {"ώ"},
+	"σ": {"ς"},
+}
+
+// accentPopup is the variant picker shown above a key while it is long-pressed.
+type accentPopup struct {
+	keyIndex int
+	options  []string
+	selected int
+}
+
+// startAccentPress defers typing the selected key when it has variants, so that holding A
+// can open the popup instead. Returns false when the key should be typed right away.
+func (kb *virtualKeyboard) startAccentPress() bool {
+	if kb.SelectedKeyIndex < 0 || kb.SelectedKeyIndex >= len(kb.Keys) || kb.CurrentState == symbolsMode {
+		return false
+	}
+	if len(kb.allowedVariants(kb.SelectedKeyIndex)) == 0 {
+		return false
+	}
+
+	kb.accentPressKey = kb.SelectedKeyIndex
+	kb.accentPressStart = time.Now()
+	return true
+}
+
+// finishAccentPress types the deferred key when A is released before the popup opened.
+func (kb *virtualKeyboard) finishAccentPress() {
+	if kb.accentPressKey < 0 {
+		return
+	}
+
+	if keyValue := kb.getKeyValue(kb.accentPressKey); kb.isTextAllowed(keyValue) {
+		kb.typeText(keyValue)
+	}
+	kb.accentPressKey = -1
+	kb.CursorVisible = true
+	kb.LastCursorBlink = time.Now()
+}
+
+// updateAccentPress opens the popup once A has been held long enough.
+func (kb *virtualKeyboard) updateAccentPress() {
+	if kb.accentPressKey < 0 || time.Since(kb.accentPressStart) < accentLongPressDelay {
+		return
+	}
+
+	index := kb.accentPressKey
+	kb.accentPressKey = -1
+
+	options := append([]string{kb.getKeyValue(index)}, kb.allowedVariants(index)...)
+	kb.accentPopup = &accentPopup{keyIndex: index, options: options, selected: 1}
+}
+
+// allowedVariants returns the key's variants in the current case, minus any that
+// AllowedRunes rejects.
+func (kb *virtualKeyboard) allowedVariants(index int) []string {
+	var variants []string
+	for _, v := range kb.Keys[index].Variants {
+		if kb.CurrentState == upperCase {
+			v = strings.ToUpper(v)
+		}
+		if kb.isTextAllowed(v) {
+			variants = append(variants, v)
+		}
+	}
+	return variants
+}
+
+func (kb *virtualKeyboard) handleAccentPopupInput(button constants.VirtualButton) bool {
+	popup := kb.accentPopup
+
+	switch button {
+	case constants.VirtualButtonUp, constants.VirtualButtonDown,
+		constants.VirtualButtonLeft, constants.VirtualButtonRight:
+		kb.directionalInput.SetHeld(button, true)
+		kb.navigate(button)
+	case constants.VirtualButtonA:
+		kb.insertText(popup.options[popup.selected])
+		kb.accentPopup = nil
+		kb.CursorVisible = true
+		kb.LastCursorBlink = time.Now()
+	case constants.VirtualButtonB:
+		kb.accentPopup = nil
+	}
+
+	return false
+}
+
+func (kb *virtualKeyboard) moveAccentSelection(button constants.VirtualButton) {
+	popup := kb.accentPopup
+
+	switch button {
+	case constants.VirtualButtonLeft, constants.VirtualButtonUp:
+		popup.selected--
+		if popup.selected < 0 {
+			popup.selected = len(popup.options) - 1
+		}
+	case constants.VirtualButtonRight, constants.VirtualButtonDown:
+		popup.selected++
+		if popup.selected >= len(popup.options) {
+			popup.selected = 0
+		}
+	}
+}
+
+func (kb *virtualKeyboard) renderAccentPopup(renderer *sdl.Renderer, font *ttf.Font) {
+	popup := kb.accentPopup
+	keyRect := kb.Keys[popup.keyIndex].Rect
+	spacing := int32(3)
+	padding := int32(6)
+
+	width := int32(len(popup.options))*(keyRect.W+spacing) - spacing + padding*2
+	height := keyRect.H + padding*2
+
+	x := keyRect.X + keyRect.W/2 - width/2
+	x = internal.Max32(kb.KeyboardRect.X, internal.Min32(x, kb.KeyboardRect.X+kb.KeyboardRect.W-width))
+	y := keyRect.Y - height - spacing
+	if y < kb.TextInputRect.Y+kb.TextInputRect.H {
+		y = keyRect.Y + keyRect.H + spacing
+	}
+
+	bgRect := sdl.Rect{X: x, Y: y, W: width, H: height}
+	renderer.SetDrawColor(30, 30, 40, 255)
+	renderer.FillRect(&bgRect)
+	renderer.SetDrawColor(100, 100, 240, 255)
+	renderer.DrawRect(&bgRect)
+
+	optionX := x + padding
+	for i, option := range popup.options {
+		rect := sdl.Rect{X: optionX, Y: y + padding, W: keyRect.W, H: keyRect.H}
+		if i == popup.selected {
+			renderer.SetDrawColor(100, 100, 240, 255)
+		} else {
+			renderer.SetDrawColor(50, 50, 60, 255)
+		}
+		renderer.FillRect(&rect)
+		renderer.SetDrawColor(70, 70, 80, 255)
+		renderer.DrawRect(&rect)
+
+		kb.renderKeyText(renderer, font, option, rect)
+		optionX += keyRect.W + spacing
+	}
+}
+
+// renderAccentMarker draws a small dot in the corner of keys that have variants.
+func (kb *virtualKeyboard) renderAccentMarker(renderer *sdl.Renderer, rect sdl.Rect) {
+	size := internal.Max32(rect.W/12, 3)
+	marker := sdl.Rect{X: rect.X + rect.W - size*2, Y: rect.Y + size, W: size, H: size}
+	renderer.SetDrawColor(160, 160, 190, 255)
+	renderer.FillRect(&marker)
+}
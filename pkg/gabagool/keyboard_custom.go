@@ -0,0 +1,340 @@
+package gabagool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// CustomSpecialKey names a special key that can be placed at either end of a custom row.
+type CustomSpecialKey string
+
+const (
+	CustomKeyBackspace CustomSpecialKey = "backspace"
+	CustomKeyEnter     CustomSpecialKey = "enter"
+	CustomKeySpace     CustomSpecialKey = "space"
+	CustomKeyShift     CustomSpecialKey = "shift"
+	CustomKeySymbol    CustomSpecialKey = "symbol"
+)
+
+// Default special key widths, in standard key widths. These match the built-in layouts.
+var defaultCustomSpecialKeyWidths = map[CustomSpecialKey]float64{
+	CustomKeyBackspace: 2,
+	CustomKeyEnter:     1.5,
+	CustomKeySpace:     8,
+	CustomKeyShift:     2,
+	CustomKeySymbol:    2,
+}
+
+// CustomKeyboardLayout declares a special-purpose keyboard such as hex entry or an
+// IP address pad. Rows are listed top to bottom and centered horizontally; each
+// special key may appear once. Use it through KeyboardOptions.CustomLayout.
+//
+// In JSON a key is either an object or a plain string for its lower value:
+//
+//	{"rows": [
+//	    {"keys": ["1", "2", "3", "A", "B", "C"], "right": "backspace"},
+//	    {"keys": ["4", "5", "6", "D", "E", "F"], "right": "enter"},
+//	    {"keys": ["7", "8", "9", "0", {"lower": ".", "symbol": ":"}]}
+//	]}
+type CustomKeyboardLayout struct {
+	Rows []CustomKeyboardRow `json:"rows"`
+
+	// SpecialKeyWidths overrides special key widths, in standard key widths
+	SpecialKeyWidths map[CustomSpecialKey]float64 `json:"special_key_widths,omitempty"`
+}
+
+// CustomKeyboardRow is a single row of a custom layout.
+type CustomKeyboardRow struct {
+	Keys []CustomKey `json:"keys"`
+
+	// KeyWidth scales the keys in this row, in standard key widths (0 = 1)
+	KeyWidth float64 `json:"key_width,omitempty"`
+
+	// Left and Right place a special key before or after the keys (empty = none)
+	Left  CustomSpecialKey `json:"left,omitempty"`
+	Right CustomSpecialKey `json:"right,omitempty"`
+}
+
+// CustomKey is a character key. Upper defaults to the upper-cased Lower value and
+// Symbol defaults to Lower.
+type CustomKey struct {
+	Lower  string `json:"lower"`
+	Upper  string `json:"upper,omitempty"`
+	Symbol string `json:"symbol,omitempty"`
+}
+
+// UnmarshalJSON accepts a plain string as shorthand for a key with only a lower value.
+func (k *CustomKey) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*k = CustomKey{Lower: value}
+		return nil
+	}
+
+	type plain CustomKey
+	return json.Unmarshal(data, (*plain)(k))
+}
+
+// CustomKeys builds keys from space separated lower values, e.g. CustomKeys("0 1 2 3").
+func CustomKeys(values string) []CustomKey {
+	fields := strings.Fields(values)
+	keys := make([]CustomKey, len(fields))
+	for i, f := range fields {
+		keys[i] = CustomKey{Lower: f}
+	}
+	return keys
+}
+
+// LoadCustomKeyboardLayout reads a custom layout from a JSON file.
+func LoadCustomKeyboardLayout(path string) (*CustomKeyboardLayout, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCustomKeyboardLayout(data)
+}
+
+// ParseCustomKeyboardLayout decodes and validates a JSON custom layout.
+func ParseCustomKeyboardLayout(data []byte) (*CustomKeyboardLayout, error) {
+	var layout CustomKeyboardLayout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("custom keyboard layout: %w", err)
+	}
+	if err := layout.validate(); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+func (l *CustomKeyboardLayout) validate() error {
+	if len(l.Rows) == 0 {
+		return fmt.Errorf("custom keyboard layout has no rows")
+	}
+
+	seen := make(map[CustomSpecialKey]bool)
+	for i, row := range l.Rows {
+		if len(row.Keys) == 0 && row.Left == "" && row.Right == "" {
+			return fmt.Errorf("custom keyboard layout row %d is empty", i+1)
+		}
+		if row.KeyWidth < 0 {
+			return fmt.Errorf("custom keyboard layout row %d has a negative key width", i+1)
+		}
+		for _, special := range []CustomSpecialKey{row.Left, row.Right} {
+			if special == "" {
+				continue
+			}
+			if _, ok := defaultCustomSpecialKeyWidths[special]; !ok {
+				return fmt.Errorf("custom keyboard layout row %d: unknown special key %q", i+1, special)
+			}
+			if seen[special] {
+				return fmt.Errorf("custom keyboard layout row %d: special key %q is used twice", i+1, special)
+			}
+			seen[special] = true
+		}
+	}
+
+	for special, width := range l.SpecialKeyWidths {
+		if _, ok := defaultCustomSpecialKeyWidths[special]; !ok {
+			return fmt.Errorf("custom keyboard layout: unknown special key %q in special_key_widths", special)
+		}
+		if width < 0 {
+			return fmt.Errorf("custom keyboard layout: special key %q has a negative width", special)
+		}
+	}
+	return nil
+}
+
+func (l *CustomKeyboardLayout) hasSpecialKey(special CustomSpecialKey) bool {
+	for _, row := range l.Rows {
+		if row.Left == special || row.Right == special {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *CustomKeyboardLayout) specialKeyUnits(special CustomSpecialKey) float64 {
+	if special == "" {
+		return 0
+	}
+	if w, ok := l.SpecialKeyWidths[special]; ok && w > 0 {
+		return w
+	}
+	return defaultCustomSpecialKeyWidths[special]
+}
+
+func (r CustomKeyboardRow) keyUnits() float64 {
+	if r.KeyWidth > 0 {
+		return r.KeyWidth
+	}
+	return 1
+}
+
+func (k CustomKey) key() key {
+	upper := k.Upper
+	if upper == "" {
+		upper = strings.ToUpper(k.Lower)
+	}
+	symbol := k.Symbol
+	if symbol == "" {
+		symbol = k.Lower
+	}
+	return key{LowerValue: k.Lower, UpperValue: upper, SymbolValue: symbol}
+}
+
+func createCustomKeyboard(windowWidth, windowHeight int32, helpExitText string, layout *CustomKeyboardLayout) *virtualKeyboard {
+	kb := &virtualKeyboard{
+		Layout:           KeyboardLayoutCustom,
+		TextBuffer:       "",
+		CurrentState:     lowerCase,
+		SelectedKeyIndex: 0,
+		SelectedSpecial:  0,
+		CursorPosition:   0,
+		CursorVisible:    true,
+		LastCursorBlink:  time.Now(),
+		CursorBlinkRate:  500 * time.Millisecond,
+		helpExitText:     helpExitText,
+		ShowingHelp:      false,
+		InputDelay:       100 * time.Millisecond,
+		lastInputTime:    time.Now(),
+		accentPressKey:   -1,
+		selectionAnchor:  -1,
+		directionalInput: internal.NewDirectionalInputWithTiming(150*time.Millisecond, 50*time.Millisecond),
+		customLayout:     layout,
+		StatusBar:        DefaultStatusBarOptions(),
+	}
+
+	kb.Keys, kb.keyLayout = createCustomKeys(layout)
+	if len(kb.Keys) == 0 {
+		kb.SelectedKeyIndex = -1
+		kb.setSelection(kb.keyLayout, 0, 0)
+	}
+	setupCustomKeyboardRects(kb, windowWidth, windowHeight)
+
+	kb.helpOverlay = newHelpOverlay("Keyboard Help", customKeyboardHelpLines(layout), helpExitText)
+	kb.helpOverlay.Lines = append(kb.helpOverlay.Lines, keyboardEditingHelpLines...)
+
+	return kb
+}
+
+func customKeyboardHelpLines(layout *CustomKeyboardLayout) []string {
+	lines := []string{
+		"• D-Pad: Navigate between keys",
+		"• A: Type the selected key",
+		"• B: Backspace",
+	}
+	if layout.hasSpecialKey(CustomKeySpace) {
+		lines = append(lines, "• X: Space")
+	} else if layout.hasSpecialKey(CustomKeySymbol) {
+		lines = append(lines, "• X: Toggle symbols")
+	}
+	return append(lines,
+		"• L1 / R1: Move cursor within text",
+		"• Select: Toggle Shift",
+		"• Y: Exit keyboard without saving",
+		"• Start: Enter (confirm input)",
+	)
+}
+
+func createCustomKeys(l *CustomKeyboardLayout) ([]key, *keyLayout) {
+	var keys []key
+	layout := &keyLayout{}
+
+	for _, row := range l.Rows {
+		var items []interface{}
+		if row.Left != "" {
+			items = append(items, string(row.Left))
+		}
+		for _, k := range row.Keys {
+			items = append(items, len(keys))
+			keys = append(keys, k.key())
+		}
+		if row.Right != "" {
+			items = append(items, string(row.Right))
+		}
+		layout.rows = append(layout.rows, items)
+	}
+
+	return keys, layout
+}
+
+func setupCustomKeyboardRects(kb *virtualKeyboard, windowWidth, windowHeight int32) {
+	l := kb.customLayout
+	dims := internal.CalculateKeyboardDimensions(windowWidth, windowHeight)
+	kb.KeyboardRect = dims.KeyboardRect()
+	kb.TextInputRect = dims.TextInputRect()
+
+	sizes := internal.CalculateKeySizes(dims, len(l.Rows)+1)
+	keySpacing := sizes.KeySpacing
+
+	// Size a standard key so the widest row fits, but no wider than a numeric keypad key
+	unitWidth := dims.KeyboardWidth / 5
+	for _, row := range l.Rows {
+		units := float64(len(row.Keys))*row.keyUnits() + l.specialKeyUnits(row.Left) + l.specialKeyUnits(row.Right)
+		items := len(row.Keys)
+		if row.Left != "" {
+			items++
+		}
+		if row.Right != "" {
+			items++
+		}
+		available := dims.KeyboardWidth - keySpacing*int32(items-1)
+		unitWidth = internal.Min32(unitWidth, int32(float64(available)/units))
+	}
+
+	specs := make([]internal.RowSpec, len(l.Rows))
+	rowWidths := make([]int32, len(l.Rows))
+	index := 0
+	for r, row := range l.Rows {
+		spec := internal.RowSpec{
+			KeyWidth: int32(float64(unitWidth) * row.keyUnits()),
+			LeftKey:  string(row.Left),
+			RightKey: string(row.Right),
+		}
+		for range row.Keys {
+			spec.KeyIndices = append(spec.KeyIndices, index)
+			index++
+		}
+		specs[r] = spec
+		rowWidths[r] = internal.CalculateRowWidth(len(spec.KeyIndices), spec.KeyWidth, keySpacing,
+			kb.customSpecialWidth(spec.LeftKey, unitWidth), kb.customSpecialWidth(spec.RightKey, unitWidth))
+	}
+
+	maxRowWidth := internal.MaxRowWidth(rowWidths...)
+	leftMargin := dims.StartX + (dims.KeyboardWidth-maxRowWidth)/2
+	y := dims.KeyboardStartY + keySpacing
+
+	for _, name := range []string{"backspace", "enter", "space", "shift", "symbol", "language"} {
+		*kb.specialKeyRect(name) = sdl.Rect{}
+	}
+
+	keyRects := make([]sdl.Rect, len(kb.Keys))
+	for r, spec := range specs {
+		x := leftMargin + (maxRowWidth-rowWidths[r])/2
+		if spec.LeftKey != "" {
+			width := kb.customSpecialWidth(spec.LeftKey, unitWidth)
+			*kb.specialKeyRect(spec.LeftKey) = sdl.Rect{X: x, Y: y, W: width, H: sizes.KeyHeight}
+			x += width + keySpacing
+		}
+		x = internal.LayoutRow(keyRects, spec.KeyIndices, x, y, spec.KeyWidth, sizes.KeyHeight, keySpacing)
+		if spec.RightKey != "" {
+			width := kb.customSpecialWidth(spec.RightKey, unitWidth)
+			*kb.specialKeyRect(spec.RightKey) = sdl.Rect{X: x, Y: y, W: width, H: sizes.KeyHeight}
+		}
+		y += sizes.KeyHeight + keySpacing
+	}
+
+	for i := range kb.Keys {
+		kb.Keys[i].Rect = keyRects[i]
+	}
+}
+
+func (kb *virtualKeyboard) customSpecialWidth(name string, unitWidth int32) int32 {
+	return int32(kb.customLayout.specialKeyUnits(CustomSpecialKey(name)) * float64(unitWidth))
+}
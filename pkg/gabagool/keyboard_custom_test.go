@@ -0,0 +1,113 @@
+package gabagool
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCustomKeyboardLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    *CustomKeyboardLayout
+		wantErr string
+	}{
+		{
+			name: "string shorthand and object keys",
+			json: `{"rows": [
+				{"keys": ["1", "2", {"lower": "a", "upper": "A", "symbol": "!"}], "right": "backspace"},
+				{"keys": [{"lower": "."}], "key_width": 1.5, "left": "shift", "right": "enter"}
+			]}`,
+			want: &CustomKeyboardLayout{Rows: []CustomKeyboardRow{
+				{Keys: []CustomKey{{Lower: "1"}, {Lower: "2"}, {Lower: "a", Upper: "A", Symbol: "!"}}, Right: CustomKeyBackspace},
+				{Keys: []CustomKey{{Lower: "."}}, KeyWidth: 1.5, Left: CustomKeyShift, Right: CustomKeyEnter},
+			}},
+		},
+		{
+			name: "special key widths",
+			json: `{"rows": [{"keys": ["0"], "right": "space"}], "special_key_widths": {"space": 3}}`,
+			want: &CustomKeyboardLayout{
+				Rows:             []CustomKeyboardRow{{Keys: []CustomKey{{Lower: "0"}}, Right: CustomKeySpace}},
+				SpecialKeyWidths: map[CustomSpecialKey]float64{CustomKeySpace: 3},
+			},
+		},
+		{
+			name: "row of only a special key",
+			json: `{"rows": [{"keys": ["0"]}, {"keys": [], "left": "enter"}]}`,
+			want: &CustomKeyboardLayout{Rows: []CustomKeyboardRow{
+				{Keys: []CustomKey{{Lower: "0"}}},
+				{Keys: []CustomKey{}, Left: CustomKeyEnter},
+			}},
+		},
+		{name: "invalid json", json: `{"rows": [`, wantErr: "custom keyboard layout:"},
+		{name: "invalid key", json: `{"rows": [{"keys": [1]}]}`, wantErr: "custom keyboard layout:"},
+		{name: "no rows", json: `{"rows": []}`, wantErr: "has no rows"},
+		{name: "empty row", json: `{"rows": [{"keys": ["1"]}, {"keys": []}]}`, wantErr: "row 2 is empty"},
+		{name: "negative key width", json: `{"rows": [{"keys": ["1"], "key_width": -1}]}`, wantErr: "row 1 has a negative key width"},
+		{name: "unknown special key", json: `{"rows": [{"keys": ["1"], "right": "tab"}]}`, wantErr: `unknown special key "tab"`},
+		{
+			name:    "duplicate special key",
+			json:    `{"rows": [{"keys": ["1"], "right": "enter"}, {"keys": ["2"], "left": "enter"}]}`,
+			wantErr: `row 2: special key "enter" is used twice`,
+		},
+		{
+			name:    "special key twice in a row",
+			json:    `{"rows": [{"keys": ["1"], "left": "shift", "right": "shift"}]}`,
+			wantErr: `special key "shift" is used twice`,
+		},
+		{
+			name:    "unknown special key width",
+			json:    `{"rows": [{"keys": ["1"]}], "special_key_widths": {"tab": 2}}`,
+			wantErr: `unknown special key "tab" in special_key_widths`,
+		},
+		{
+			name:    "negative special key width",
+			json:    `{"rows": [{"keys": ["1"], "right": "enter"}], "special_key_widths": {"enter": -2}}`,
+			wantErr: `special key "enter" has a negative width`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCustomKeyboardLayout([]byte(tt.json))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("layout = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomKeyDefaults(t *testing.T) {
+	tests := []struct {
+		key                  CustomKey
+		lower, upper, symbol string
+	}{
+		{key: CustomKey{Lower: "a"}, lower: "a", upper: "A", symbol: "a"},
+		{key: CustomKey{Lower: "1"}, lower: "1", upper: "1", symbol: "1"},
+		{key: CustomKey{Lower: "ß", Upper: "ẞ", Symbol: "§"}, lower: "ß", upper: "ẞ", symbol: "§"},
+	}
+
+	for _, tt := range tests {
+		k := tt.key.key()
+		if k.LowerValue != tt.lower || k.UpperValue != tt.upper || k.SymbolValue != tt.symbol {
+			t.Errorf("%+v.key() = %q/%q/%q, want %q/%q/%q", tt.key, k.LowerValue, k.UpperValue, k.SymbolValue, tt.lower, tt.upper, tt.symbol)
+		}
+	}
+}
+
+func TestCustomKeys(t *testing.T) {
+	want := []CustomKey{{Lower: "0"}, {Lower: "1"}, {Lower: "ff"}}
+	if got := CustomKeys(" 0 1\tff "); !reflect.DeepEqual(got, want) {
+		t.Errorf("CustomKeys = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,188 @@
+package gabagool
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+)
+
+var keyboardEditingHelpLines = []string{
+	"• Select + L1 / R1: Move cursor by word",
+	"• Select + L2 / R2: Select by word",
+	"• Select + X / Y / A: Cut / copy / paste",
+	"• Select + B: Clear all text",
+}
+
+// selectionRange returns the selected rune range, if any.
+func (kb *virtualKeyboard) selectionRange() (start, end int, ok bool) {
+	if kb.selectionAnchor < 0 || kb.selectionAnchor == kb.CursorPosition {
+		return 0, 0, false
+	}
+	return min(kb.selectionAnchor, kb.CursorPosition), max(kb.selectionAnchor, kb.CursorPosition), true
+}
+
+func (kb *virtualKeyboard) selectedText() string {
+	start, end, ok := kb.selectionRange()
+	if !ok {
+		return ""
+	}
+	return string([]rune(kb.TextBuffer)[start:end])
+}
+
+// deleteSelection removes the selected text. Returns false when nothing was selected.
+func (kb *virtualKeyboard) deleteSelection() bool {
+	start, end, ok := kb.selectionRange()
+	kb.selectionAnchor = -1
+	if !ok {
+		return false
+	}
+
+	textRunes := []rune(kb.TextBuffer)
+	kb.TextBuffer = string(textRunes[:start]) + string(textRunes[end:])
+	kb.CursorPosition = start
+	return true
+}
+
+// extendSelectionTo moves the cursor while keeping the selection anchored where it started.
+func (kb *virtualKeyboard) extendSelectionTo(position int) {
+	kb.commitComposition()
+
+	if kb.selectionAnchor < 0 {
+		kb.selectionAnchor = kb.CursorPosition
+	}
+	kb.CursorPosition = max(0, min(position, utf8.RuneCountInString(kb.TextBuffer)))
+	kb.CursorVisible = true
+	kb.LastCursorBlink = time.Now()
+}
+
+// moveWord jumps the cursor to the previous word start or the next word end.
+func (kb *virtualKeyboard) moveWord(direction int, extend bool) {
+	kb.commitComposition()
+
+	textRunes := []rune(kb.TextBuffer)
+	target := internal.NextWordEnd(textRunes, kb.CursorPosition)
+	if direction < 0 {
+		target = internal.PreviousWordStart(textRunes, kb.CursorPosition)
+	}
+
+	if extend {
+		kb.extendSelectionTo(target)
+	} else {
+		kb.moveCursorTo(target)
+	}
+}
+
+func (kb *virtualKeyboard) selectAll() {
+	kb.commitComposition()
+	kb.selectionAnchor = 0
+	kb.CursorPosition = utf8.RuneCountInString(kb.TextBuffer)
+}
+
+// copySelection copies the selection, or all text when nothing is selected.
+// Masked input is never copied.
+func (kb *virtualKeyboard) copySelection() {
+	if kb.options.Masked {
+		return
+	}
+
+	text := kb.selectedText()
+	if text == "" {
+		text = kb.TextBuffer
+	}
+	if text != "" {
+		setClipboardText(text)
+	}
+}
+
+// cutSelection cuts the selection, or all text when nothing is selected.
+func (kb *virtualKeyboard) cutSelection() {
+	if kb.options.Masked {
+		return
+	}
+
+	kb.commitComposition()
+	kb.copySelection()
+	if !kb.deleteSelection() {
+		kb.clearAll()
+	}
+}
+
+// paste inserts the clipboard at the cursor, replacing any selection.
+// Line breaks are flattened unless the keyboard edits multiple lines.
+func (kb *virtualKeyboard) paste() {
+	text := getClipboardText()
+	if text == "" {
+		return
+	}
+
+	if kb.textArea != nil {
+		text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+	} else {
+		text = strings.Join(strings.Fields(text), " ")
+	}
+	kb.insertText(text)
+}
+
+func (kb *virtualKeyboard) clearAll() {
+	kb.romajiKana = ""
+	kb.romajiPending = ""
+	kb.TextBuffer = ""
+	kb.CursorPosition = 0
+	kb.selectionAnchor = -1
+}
+
+// deleteWordBackward removes the word before the cursor, or the selection if there is one.
+func (kb *virtualKeyboard) deleteWordBackward() {
+	kb.commitComposition()
+
+	if kb.deleteSelection() {
+		return
+	}
+	kb.selectionAnchor = kb.CursorPosition
+	kb.CursorPosition = internal.PreviousWordStart([]rune(kb.TextBuffer), kb.CursorPosition)
+	kb.deleteSelection()
+}
+
+// handleSelectChord runs the editing shortcuts available while Select is held.
+// Returns false for buttons that have no chord, which then behave normally.
+func (kb *virtualKeyboard) handleSelectChord(button constants.VirtualButton) bool {
+	switch button {
+	case constants.VirtualButtonL1:
+		kb.moveWord(-1, false)
+	case constants.VirtualButtonR1:
+		kb.moveWord(1, false)
+	case constants.VirtualButtonL2:
+		kb.moveWord(-1, true)
+	case constants.VirtualButtonR2:
+		kb.moveWord(1, true)
+	case constants.VirtualButtonX:
+		kb.cutSelection()
+	case constants.VirtualButtonY:
+		kb.copySelection()
+	case constants.VirtualButtonA:
+		kb.paste()
+	case constants.VirtualButtonB:
+		kb.clearAll()
+	case constants.VirtualButtonUp, constants.VirtualButtonDown:
+		direction := 1
+		if button == constants.VirtualButtonUp {
+			direction = -1
+		}
+		if kb.textArea != nil {
+			kb.moveLine(direction, false)
+		} else if kb.history != nil {
+			kb.cycleHistory(direction)
+		} else {
+			return false
+		}
+	default:
+		return false
+	}
+
+	kb.CursorVisible = true
+	kb.LastCursorBlink = time.Now()
+	return true
+}
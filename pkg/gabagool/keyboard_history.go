@@ -0,0 +1,152 @@
+package gabagool
+
+import (
+	"unicode/utf8"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+)
+
+// Keyboard input history.
+//
+// Keyboards opened with KeyboardOptions.HistoryKey remember confirmed input in a small
+// JSON file shared by all keys, or only in memory until SetKeyboardHistoryPath is called.
+// Select + Up/Down (or the arrow keys while typing on a
+// physical keyboard) step through earlier entries, most recent first.
+
+const defaultKeyboardHistoryLimit = 20
+
+var keyboardHistoryHelpLine = "• Select + Up / Down: Previous entries"
+
+// keyboardHistoryStore holds the entries of each history key, most recent first.
+var keyboardHistoryStore internal.JSONStore[[]string]
+
+// keyboardHistory is the browsing state of a keyboard with a history key.
+type keyboardHistory struct {
+	key     string
+	limit   int
+	entries []string
+	index   int // -1 while editing the draft
+	draft   string
+}
+
+// SetKeyboardHistoryPath sets the file keyboard history is stored in. Until it is set,
+// history is kept in memory and lost when the app exits. Parent directories are
+// created when history is first saved.
+func SetKeyboardHistoryPath(path string) {
+	keyboardHistoryStore.SetPath(path)
+}
+
+// KeyboardHistory returns the stored entries for a history key, most recent first.
+func KeyboardHistory(key string) []string {
+	entries, _ := keyboardHistoryStore.Get(key)
+	return entries
+}
+
+// ClearKeyboardHistory removes all entries for a history key.
+func ClearKeyboardHistory(key string) error {
+	return keyboardHistoryStore.Update(func(store map[string][]string) bool {
+		if _, ok := store[key]; !ok {
+			return false
+		}
+		delete(store, key)
+		return true
+	})
+}
+
+// RemoveKeyboardHistoryEntry removes a single entry from a history key.
+func RemoveKeyboardHistoryEntry(key, entry string) error {
+	return keyboardHistoryStore.Update(func(store map[string][]string) bool {
+		entries := store[key][:0:0]
+		for _, e := range store[key] {
+			if e != entry {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) == len(store[key]) {
+			return false
+		}
+
+		if len(entries) == 0 {
+			delete(store, key)
+		} else {
+			store[key] = entries
+		}
+		return true
+	})
+}
+
+func addKeyboardHistoryEntry(key, entry string, limit int) error {
+	return keyboardHistoryStore.Update(func(store map[string][]string) bool {
+		store[key] = internal.PushRecent(store[key], entry, limit)
+		return true
+	})
+}
+
+// enableHistory loads the entries for key. Masked keyboards never keep history.
+func (kb *virtualKeyboard) enableHistory(key string, limit int) {
+	if key == "" || kb.options.Masked {
+		return
+	}
+	if limit <= 0 {
+		limit = defaultKeyboardHistoryLimit
+	}
+
+	// Entries saved by a less restricted keyboard sharing the key are left out
+	var entries []string
+	for _, entry := range KeyboardHistory(key) {
+		if kb.fitsOptions(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	kb.history = &keyboardHistory{
+		key:     key,
+		limit:   limit,
+		entries: entries,
+		index:   -1,
+	}
+
+	if kb.helpOverlay != nil {
+		kb.helpOverlay.Lines = append(append([]string(nil), kb.helpOverlay.Lines...), keyboardHistoryHelpLine)
+	}
+}
+
+// cycleHistory replaces the text with an older (direction < 0) or newer entry.
+// Stepping past the newest entry restores the text that was being typed.
+func (kb *virtualKeyboard) cycleHistory(direction int) {
+	h := kb.history
+	if h == nil || len(h.entries) == 0 {
+		return
+	}
+
+	kb.commitComposition()
+
+	index := h.index - direction
+	if index < -1 || index >= len(h.entries) {
+		return
+	}
+	if h.index == -1 {
+		h.draft = kb.TextBuffer
+	}
+	h.index = index
+
+	text := h.draft
+	if index >= 0 {
+		text = h.entries[index]
+	}
+	kb.TextBuffer = text
+	kb.CursorPosition = utf8.RuneCountInString(text)
+	kb.selectionAnchor = -1
+}
+
+// recordHistory stores confirmed text under the history key.
+func (kb *virtualKeyboard) recordHistory(text string) {
+	h := kb.history
+	if h == nil || text == "" {
+		return
+	}
+
+	if err := addKeyboardHistoryEntry(h.key, text, h.limit); err != nil {
+		internal.GetInternalLogger().Error("Failed to save keyboard history", "key", h.key, "error", err)
+	}
+}
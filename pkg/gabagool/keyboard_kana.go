@@ -0,0 +1,89 @@
+package gabagool
+
+import (
+	"unicode/utf8"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+)
+
+// romajiActive reports whether typed letters go through the romaji composer.
+// Shift switches the composer between hiragana and katakana.
+func (kb *virtualKeyboard) romajiActive() bool {
+	return len(kb.languages) > 0 && kb.languages[kb.languageIndex].Romaji && kb.CurrentState != symbolsMode
+}
+
+// typeText inserts a key value, routing letters into the kana composition when
+// the active layout uses romaji.
+func (kb *virtualKeyboard) typeText(text string) {
+	if kb.romajiActive() && internal.IsRomajiInput(text) {
+		kb.deleteSelection()
+		if kb.isFull() {
+			return
+		}
+		kana, pending := internal.RomajiToKana(kb.romajiPending+text, kb.ShiftPressed)
+		kb.romajiKana += kana
+		kb.romajiPending = pending
+		return
+	}
+	kb.insertText(text)
+}
+
+// compositionText returns the uncommitted text shown at the cursor: kana being
+// composed from romaji, followed by any IME composition from a physical keyboard.
+func (kb *virtualKeyboard) compositionText() string {
+	return kb.romajiKana + kb.romajiPending + kb.imeComposition
+}
+
+// commitComposition moves the in-progress kana into the text buffer.
+// IME compositions are committed by the IME itself.
+func (kb *virtualKeyboard) commitComposition() {
+	if kb.romajiKana == "" && kb.romajiPending == "" {
+		return
+	}
+
+	text := kb.romajiKana + internal.FlushRomaji(kb.romajiPending, kb.ShiftPressed)
+	kb.romajiKana = ""
+	kb.romajiPending = ""
+	kb.insertText(text)
+}
+
+// backspaceComposition removes the last composed character.
+// Returns false when there is no composition to edit.
+func (kb *virtualKeyboard) backspaceComposition() bool {
+	if kb.romajiPending != "" {
+		kb.romajiPending = trimLastRune(kb.romajiPending)
+		return true
+	}
+	if kb.romajiKana != "" {
+		kb.romajiKana = trimLastRune(kb.romajiKana)
+		return true
+	}
+	return false
+}
+
+// convertCompositionScript switches the composed kana to match the shift state.
+func (kb *virtualKeyboard) convertCompositionScript() {
+	if kb.ShiftPressed {
+		kb.romajiKana = internal.ToKatakana(kb.romajiKana)
+	} else {
+		kb.romajiKana = internal.ToHiragana(kb.romajiKana)
+	}
+}
+
+// displayText returns the text shown in the input field with any composition spliced in
+// at the cursor, along with the rune range the composition occupies.
+func (kb *virtualKeyboard) displayText() (text string, compositionStart, compositionEnd int) {
+	composition := kb.compositionText()
+	if composition == "" {
+		return kb.TextBuffer, kb.CursorPosition, kb.CursorPosition
+	}
+
+	runes := []rune(kb.TextBuffer)
+	text = string(runes[:kb.CursorPosition]) + composition + string(runes[kb.CursorPosition:])
+	return text, kb.CursorPosition, kb.CursorPosition + utf8.RuneCountInString(composition)
+}
+
+func trimLastRune(s string) string {
+	_, size := utf8.DecodeLastRuneInString(s)
+	return s[:len(s)-size]
+}
@@ -0,0 +1,259 @@
+package gabagool
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/i18n"
+	"golang.org/x/text/language"
+)
+
+// KeyboardLanguage identifies a character layout for the general keyboard.
+type KeyboardLanguage string
+
+const (
+	// KeyboardLanguageQWERTY is the US/UK English layout.
+	KeyboardLanguageQWERTY KeyboardLanguage = "qwerty"
+	// KeyboardLanguageAZERTY is the French layout.
+	KeyboardLanguageAZERTY KeyboardLanguage = "azerty"
+	// KeyboardLanguageQWERTZ is the German layout.
+	KeyboardLanguageQWERTZ KeyboardLanguage = "qwertz"
+	// KeyboardLanguageNordic covers Swedish, Finnish, Norwegian and Danish.
+	KeyboardLanguageNordic KeyboardLanguage = "nordic"
+	// KeyboardLanguageCyrillic is the Russian ЙЦУКЕН layout.
+	KeyboardLanguageCyrillic KeyboardLanguage = "cyrillic"
+	// KeyboardLanguageGreek is the Greek layout.
+	KeyboardLanguageGreek KeyboardLanguage = "greek"
+	// KeyboardLanguageJapanese types kana by converting romaji as it is entered.
+	KeyboardLanguageJapanese KeyboardLanguage = "japanese"
+)
+
+// KeyboardLanguageRow is a single row of character keys.
+// Upper and Symbol are optional; missing entries fall back to the upper-cased
+// Lower value and the Lower value respectively.
+type KeyboardLanguageRow struct {
+	Lower  []string `json:"lower"`
+	Upper  []string `json:"upper,omitempty"`
+	Symbol []string `json:"symbol,omitempty"`
+}
+
+// KeyboardLanguageLayout defines the character planes of a general keyboard layout.
+// Rows are listed top to bottom. The first row gets the backspace key, the second to
+// last row gets the enter key and the last row is framed by the shift and symbol keys.
+type KeyboardLanguageLayout struct {
+	Language KeyboardLanguage      `json:"language"`
+	Label    string                `json:"label"` // Shown on the layout switch key
+	Rows     []KeyboardLanguageRow `json:"rows"`
+
+	// Variants maps a lower-case key value to the accented characters offered when
+	// A is held on that key. Upper-case variants are derived automatically.
+	Variants map[string][]string `json:"variants,omitempty"`
+
+	// Romaji converts typed letters to kana ("ka" -> か). The composition stays
+	// underlined until it is committed, and shift switches to katakana.
+	Romaji bool `json:"romaji,omitempty"`
+}
+
+func (l KeyboardLanguageLayout) validate() error {
+	if l.Language == "" {
+		return fmt.Errorf("keyboard layout has no language")
+	}
+	if len(l.Rows) < 3 {
+		return fmt.Errorf("keyboard layout %q needs at least 3 rows, got %d", l.Language, len(l.Rows))
+	}
+	for i, row := range l.Rows {
+		if len(row.Lower) == 0 {
+			return fmt.Errorf("keyboard layout %q row %d is empty", l.Language, i+1)
+		}
+	}
+	return nil
+}
+
+var (
+	keyboardLanguagesMu sync.RWMutex
+	keyboardLanguages   = map[KeyboardLanguage]KeyboardLanguageLayout{}
+
+	defaultKeyboardLanguages []KeyboardLanguage
+)
+
+func init() {
+	for _, l := range builtinKeyboardLanguages {
+		keyboardLanguages[l.Language] = l
+	}
+}
+
+// RegisterKeyboardLanguage adds a layout or replaces an existing one with the same Language.
+func RegisterKeyboardLanguage(layout KeyboardLanguageLayout) error {
+	if err := layout.validate(); err != nil {
+		return err
+	}
+
+	keyboardLanguagesMu.Lock()
+	defer keyboardLanguagesMu.Unlock()
+	keyboardLanguages[layout.Language] = layout
+	return nil
+}
+
+// GetKeyboardLanguage returns the registered layout for a language.
+func GetKeyboardLanguage(lang KeyboardLanguage) (KeyboardLanguageLayout, bool) {
+	keyboardLanguagesMu.RLock()
+	defer keyboardLanguagesMu.RUnlock()
+	l, ok := keyboardLanguages[lang]
+	return l, ok
+}
+
+// SetDefaultKeyboardLanguages sets the layouts the general keyboard cycles through when
+// none are passed per call. With no arguments the layouts are derived from the active
+// i18n locale again.
+func SetDefaultKeyboardLanguages(languages ...KeyboardLanguage) {
+	keyboardLanguagesMu.Lock()
+	defer keyboardLanguagesMu.Unlock()
+	defaultKeyboardLanguages = append([]KeyboardLanguage(nil), languages...)
+}
+
+// KeyboardLanguageForLocale returns the layout that best matches a locale.
+// Unknown locales get QWERTY.
+func KeyboardLanguageForLocale(tag language.Tag) KeyboardLanguage {
+	base, _ := tag.Base()
+	switch base.String() {
+	case "fr":
+		return KeyboardLanguageAZERTY
+	case "de":
+		return KeyboardLanguageQWERTZ
+	case "sv", "fi", "no", "nb", "nn", "da", "is":
+		return KeyboardLanguageNordic
+	case "ru", "uk", "be", "bg", "kk":
+		return KeyboardLanguageCyrillic
+	case "el":
+		return KeyboardLanguageGreek
+	case "ja":
+		return KeyboardLanguageJapanese
+	default:
+		return KeyboardLanguageQWERTY
+	}
+}
+
+// resolveKeyboardLanguages returns the layouts to enable for a keyboard.
+// Explicit languages win, then the package defaults, then the i18n locale
+// (with QWERTY as a second layout for non-English locales).
+// Unregistered languages are skipped.
+func resolveKeyboardLanguages(languages []KeyboardLanguage) []KeyboardLanguageLayout {
+	keyboardLanguagesMu.RLock()
+	defer keyboardLanguagesMu.RUnlock()
+
+	if len(languages) == 0 {
+		languages = defaultKeyboardLanguages
+	}
+	if len(languages) == 0 {
+		languages = []KeyboardLanguage{KeyboardLanguageForLocale(i18n.CurrentLanguage())}
+		if languages[0] != KeyboardLanguageQWERTY {
+			languages = append(languages, KeyboardLanguageQWERTY)
+		}
+	}
+
+	var layouts []KeyboardLanguageLayout
+	seen := make(map[KeyboardLanguage]bool)
+	for _, lang := range languages {
+		l, ok := keyboardLanguages[lang]
+		if !ok || seen[lang] {
+			continue
+		}
+		seen[lang] = true
+		layouts = append(layouts, l)
+	}
+
+	if len(layouts) == 0 {
+		layouts = append(layouts, keyboardLanguages[KeyboardLanguageQWERTY])
+	}
+	return layouts
+}
+
+// keyRow builds a row from space separated planes; an empty plane is left unset.
+func keyRow(lower, upper, symbol string) KeyboardLanguageRow {
+	return KeyboardLanguageRow{
+		Lower:  strings.Fields(lower),
+		Upper:  strings.Fields(upper),
+		Symbol: strings.Fields(symbol),
+	}
+}
+
+var builtinKeyboardLanguages = []KeyboardLanguageLayout{
+	{
+		Language: KeyboardLanguageQWERTY,
+		Label:    "EN",
+		Rows: []KeyboardLanguageRow{
+			keyRow("1 2 3 4 5 6 7 8 9 0", "! @ # $ % ^ & * ( )", "! @ # $ % ^ & * ( )"),
+			keyRow("q w e r t y u i o p", "", "` ~ [ ] \\ | { } ; :"),
+			keyRow("a s d f g h j k l", "", "' \" < > ? / + = _"),
+			keyRow("z x c v b n m", "", ", . - € £ ¥ ¢"),
+		},
+		Variants: latinAccentVariants,
+	},
+	{
+		Language: KeyboardLanguageAZERTY,
+		Label:    "FR",
+		Rows: []KeyboardLanguageRow{
+			keyRow("1 2 3 4 5 6 7 8 9 0", "& é \" ' ( - è _ ç à", "! @ # $ % ^ * ° + ="),
+			keyRow("a z e r t y u i o p", "", "` ~ [ ] \\ | { } « »"),
+			keyRow("q s d f g h j k l m", "", "' \" < > ? / ; : _ ù"),
+			keyRow("w x c v b n ,", "W X C V B N ?", ". - € £ µ § ¨"),
+		},
+		Variants: latinAccentVariants,
+	},
+	{
+		Language: KeyboardLanguageQWERTZ,
+		Label:    "DE",
+		Rows: []KeyboardLanguageRow{
+			keyRow("1 2 3 4 5 6 7 8 9 0", "! \" § $ % & / ( ) =", "! \" § $ % & / ( ) ="),
+			keyRow("q w e r t z u i o p ü", "", "` ~ [ ] \\ | { } ; : @"),
+			keyRow("a s d f g h j k l ö ä", "", "' ^ < > ? / + = _ # *"),
+			keyRow("y x c v b n m ß", "Y X C V B N M ß", ", . - € £ ¥ ° µ"),
+		},
+		Variants: latinAccentVariants,
+	},
+	{
+		Language: KeyboardLanguageNordic,
+		Label:    "NO",
+		Rows: []KeyboardLanguageRow{
+			keyRow("1 2 3 4 5 6 7 8 9 0", "! \" # ¤ % & / ( ) =", "! @ # $ % & / ( ) ="),
+			keyRow("q w e r t y u i o p å", "", "` ~ [ ] \\ | { } ; : ^"),
+			keyRow("a s d f g h j k l ö ä", "", "' \" < > ? / + = _ * §"),
+			keyRow("z x c v b n m æ ø", "", ", . - € £ ¥ ¢ ½ µ"),
+		},
+		Variants: latinAccentVariants,
+	},
+	{
+		Language: KeyboardLanguageCyrillic,
+		Label:    "RU",
+		Rows: []KeyboardLanguageRow{
+			keyRow("1 2 3 4 5 6 7 8 9 0", "! \" № ; % : ? * ( )", "! @ # $ % ^ & * ( )"),
+			keyRow("й ц у к е н г ш щ з х ъ", "", "` ~ [ ] \\ | { } ; : < >"),
+			keyRow("ф ы в а п р о л д ж э", "", "' \" < > ? / + = _ , ."),
+			keyRow("я ч с м и т ь б ю ё", "", ", . - € £ ₽ ¥ ¢ « »"),
+		},
+		Variants: cyrillicAccentVariants,
+	},
+	{
+		Language: KeyboardLanguageGreek,
+		Label:    "EL",
+		Rows: []KeyboardLanguageRow{
+			keyRow("1 2 3 4 5 6 7 8 9 0", "! @ # $ % ^ & * ( )", "! @ # $ % ^ & * ( )"),
+			keyRow("; ς ε ρ τ υ θ ι ο π", ": Σ Ε Ρ Τ Υ Θ Ι Ο Π", "` ~ [ ] \\ | { } ; :"),
+			keyRow("α σ δ φ γ η ξ κ λ", "", "' \" < > ? / + = _"),
+			keyRow("ζ χ ψ ω β ν μ", "", ", . - € £ ¥ ¢"),
+		},
+		Variants: greekAccentVariants,
+	},
+	{
+		Language: KeyboardLanguageJapanese,
+		Label:    "かな",
+		Romaji:   true,
+		Rows: []KeyboardLanguageRow{
+			keyRow("1 2 3 4 5 6 7 8 9 0", "1 2 3 4 5 6 7 8 9 0", "! @ # $ % ^ & * ( )"),
+			keyRow("q w e r t y u i o p", "q w e r t y u i o p", "「 」 『 』 【 】 ・ ～ ： ；"),
+			keyRow("a s d f g h j k l", "a s d f g h j k l", "、 。 ー ！ ？ ／ ＋ ＝ ＿"),
+			keyRow("z x c v b n m", "z x c v b n m", "… ￥ ※ 〒 ♪ ＠ ＆"),
+		},
+	},
+}
@@ -0,0 +1,154 @@
+package gabagool
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// KeyboardOptions holds input constraints and presentation settings shared by
+// KeyboardWithOptions and URLKeyboard.
+type KeyboardOptions struct {
+	// Layout used by KeyboardWithOptions (ignored by URLKeyboard)
+	Layout KeyboardLayout
+
+	// CustomLayout replaces the built-in layouts when set (ignored by URLKeyboard).
+	// Load one from JSON with LoadCustomKeyboardLayout or declare it in Go.
+	CustomLayout *CustomKeyboardLayout
+
+	// Languages enabled on the general layout.
+	// If empty, SetDefaultKeyboardLanguages or the active i18n locale decides.
+	Languages []KeyboardLanguage
+
+	// Suggestions feeds the suggestion strip above the keys. Ignored when Masked is set.
+	Suggestions SuggestionProvider
+
+	// MaxLength caps the text at this many characters (0 = unlimited)
+	MaxLength int
+
+	// AllowedRunes restricts which characters can be entered (nil = any).
+	// Keys that would type a disallowed character are greyed out.
+	AllowedRunes func(r rune) bool
+
+	// Masked hides the text behind bullets. Holding R2 reveals it.
+	Masked bool
+
+	// Placeholder is shown while the text field is empty
+	Placeholder string
+
+	// HistoryKey enables input history: confirmed text is saved under this key and
+	// Select + Up/Down recalls earlier entries. Ignored when Masked is set.
+	HistoryKey string
+
+	// HistoryLimit caps the entries kept for HistoryKey (0 = 20)
+	HistoryLimit int
+}
+
+// AllowRunes returns an AllowedRunes filter accepting only the characters in set.
+func AllowRunes(set string) func(r rune) bool {
+	return func(r rune) bool {
+		return strings.ContainsRune(set, r)
+	}
+}
+
+// applyOptions attaches options to a freshly created keyboard.
+func (kb *virtualKeyboard) applyOptions(options KeyboardOptions) {
+	kb.options = options
+	if !options.Masked {
+		kb.enableSuggestions(options.Suggestions)
+	}
+	kb.enableHistory(options.HistoryKey, options.HistoryLimit)
+}
+
+// isTextAllowed reports whether every character of text passes AllowedRunes.
+func (kb *virtualKeyboard) isTextAllowed(text string) bool {
+	if kb.options.AllowedRunes == nil {
+		return true
+	}
+	for _, r := range text {
+		if !kb.options.AllowedRunes(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// fitsOptions reports whether text could have been typed on this keyboard: no longer
+// than MaxLength and made only of allowed characters.
+func (kb *virtualKeyboard) fitsOptions(text string) bool {
+	if kb.options.MaxLength > 0 && utf8.RuneCountInString(text) > kb.options.MaxLength {
+		return false
+	}
+	return kb.isTextAllowed(text)
+}
+
+// constrainText drops disallowed characters and truncates text to the remaining length.
+func (kb *virtualKeyboard) constrainText(text string) string {
+	if kb.options.AllowedRunes != nil {
+		text = strings.Map(func(r rune) rune {
+			if kb.options.AllowedRunes(r) {
+				return r
+			}
+			return -1
+		}, text)
+	}
+
+	if kb.options.MaxLength > 0 {
+		remaining := kb.options.MaxLength - utf8.RuneCountInString(kb.TextBuffer)
+		if remaining <= 0 {
+			return ""
+		}
+		if runes := []rune(text); len(runes) > remaining {
+			text = string(runes[:remaining])
+		}
+	}
+
+	return text
+}
+
+// isFull reports whether the text has reached MaxLength.
+func (kb *virtualKeyboard) isFull() bool {
+	if kb.options.MaxLength <= 0 {
+		return false
+	}
+	return utf8.RuneCountInString(kb.TextBuffer)+utf8.RuneCountInString(kb.romajiKana) >= kb.options.MaxLength
+}
+
+// maskText replaces text with bullets unless the user is holding the reveal button.
+func (kb *virtualKeyboard) maskText(text string) string {
+	if !kb.options.Masked || kb.revealing {
+		return text
+	}
+	return strings.Repeat("•", utf8.RuneCountInString(text))
+}
+
+func (kb *virtualKeyboard) renderPlaceholder(renderer *sdl.Renderer, font *ttf.Font, padding int32) {
+	textColor := sdl.Color{R: 130, G: 130, B: 130, A: 255}
+	textSurface, err := font.RenderUTF8Blended(kb.options.Placeholder, textColor)
+	if err != nil {
+		return
+	}
+	defer textSurface.Free()
+
+	textTexture, err := renderer.CreateTextureFromSurface(textSurface)
+	if err != nil {
+		return
+	}
+	defer textTexture.Destroy()
+
+	visibleWidth := kb.TextInputRect.W - (padding * 2)
+	srcRect := &sdl.Rect{X: 0, Y: 0, W: textSurface.W, H: textSurface.H}
+	if srcRect.W > visibleWidth {
+		srcRect.W = visibleWidth
+	}
+
+	textRect := sdl.Rect{
+		X: kb.TextInputRect.X + padding,
+		Y: kb.TextInputRect.Y + (kb.TextInputRect.H-textSurface.H)/2,
+		W: srcRect.W,
+		H: textSurface.H,
+	}
+	renderer.Copy(textTexture, srcRect, &textRect)
+}
@@ -0,0 +1,157 @@
+package gabagool
+
+import (
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Physical keyboard typing.
+//
+// SDL text input runs while a keyboard is on screen, but typed text is only accepted once
+// a key that isn't bound to a virtual button is pressed (or an IME starts composing).
+// From then on keyboard events edit the text directly, until a controller button is
+// used or Escape is pressed. Devices that expose their buttons as keys keep navigating
+// the on-screen layout.
+
+func (kb *virtualKeyboard) startPhysicalInput() {
+	sdl.SetTextInputRect(&kb.TextInputRect)
+	sdl.StartTextInput()
+}
+
+func (kb *virtualKeyboard) stopPhysicalInput() {
+	sdl.StopTextInput()
+	kb.physicalTyping = false
+	kb.imeComposition = ""
+}
+
+// handlePhysicalKeyEvent edits the text for keys typed on a physical keyboard.
+// Returns handled when the event must not be mapped to a virtual button, and exit
+// when Enter confirmed the input.
+func (kb *virtualKeyboard) handlePhysicalKeyEvent(e *sdl.KeyboardEvent) (handled, exit bool) {
+	if !kb.physicalTyping {
+		if e.Type != sdl.KEYDOWN || !isTypingKey(e.Keysym.Sym) || internal.GetInputProcessor().IsKeyMapped(e.Keysym.Sym) {
+			return false, false
+		}
+		kb.physicalTyping = true
+	}
+
+	// The IME owns the editing keys while it is composing
+	if e.Type != sdl.KEYDOWN || kb.imeComposition != "" {
+		return true, false
+	}
+
+	ctrl := e.Keysym.Mod&sdl.KMOD_CTRL != 0
+	shift := e.Keysym.Mod&sdl.KMOD_SHIFT != 0
+
+	switch e.Keysym.Sym {
+	case sdl.K_BACKSPACE:
+		if ctrl {
+			kb.deleteWordBackward()
+		} else {
+			kb.backspace()
+		}
+	case sdl.K_DELETE:
+		kb.deleteForward()
+	case sdl.K_LEFT, sdl.K_RIGHT:
+		direction := 1
+		if e.Keysym.Sym == sdl.K_LEFT {
+			direction = -1
+		}
+		switch {
+		case ctrl:
+			kb.moveWord(direction, shift)
+		case shift:
+			kb.extendSelectionTo(kb.CursorPosition + direction)
+		default:
+			kb.moveCursor(direction)
+		}
+	case sdl.K_UP, sdl.K_DOWN:
+		direction := 1
+		if e.Keysym.Sym == sdl.K_UP {
+			direction = -1
+		}
+		if kb.textArea != nil {
+			kb.moveLine(direction, shift)
+		} else {
+			kb.cycleHistory(direction)
+		}
+	case sdl.K_HOME:
+		if shift {
+			kb.extendSelectionTo(kb.lineStart())
+		} else {
+			kb.moveCursorTo(kb.lineStart())
+		}
+	case sdl.K_END:
+		if shift {
+			kb.extendSelectionTo(kb.lineEnd())
+		} else {
+			kb.moveCursorTo(kb.lineEnd())
+		}
+	case sdl.K_a, sdl.K_c, sdl.K_x, sdl.K_v:
+		if !ctrl {
+			// Plain letters arrive as text input events
+			return true, false
+		}
+		switch e.Keysym.Sym {
+		case sdl.K_a:
+			kb.selectAll()
+		case sdl.K_c:
+			kb.copySelection()
+		case sdl.K_x:
+			kb.cutSelection()
+		case sdl.K_v:
+			kb.paste()
+		}
+	case sdl.K_TAB:
+		kb.acceptSuggestion()
+	case sdl.K_RETURN, sdl.K_KP_ENTER:
+		// The text editor takes Enter as a line break; Ctrl+Enter saves
+		if kb.textArea != nil && !ctrl {
+			kb.insertText("\n")
+			break
+		}
+		kb.EnterPressed = true
+		return true, true
+	case sdl.K_ESCAPE:
+		kb.physicalTyping = false
+	}
+
+	kb.CursorVisible = true
+	kb.LastCursorBlink = time.Now()
+	return true, false
+}
+
+// handleTextInputEvent inserts text committed by the OS (a key press or a finished IME composition).
+func (kb *virtualKeyboard) handleTextInputEvent(e *sdl.TextInputEvent) {
+	if !kb.physicalTyping {
+		return
+	}
+
+	kb.imeComposition = ""
+	kb.typeText(e.GetText())
+	kb.CursorVisible = true
+	kb.LastCursorBlink = time.Now()
+}
+
+// handleTextEditingEvent tracks an in-progress IME composition.
+func (kb *virtualKeyboard) handleTextEditingEvent(e *sdl.TextEditingEvent) {
+	text := e.GetText()
+	if text != "" {
+		kb.physicalTyping = true
+	}
+	kb.imeComposition = text
+}
+
+// isTypingKey reports whether a key produces text or edits it.
+// Arrow keys, Enter and Escape are left out so they keep driving navigation.
+func isTypingKey(key sdl.Keycode) bool {
+	switch key {
+	case sdl.K_BACKSPACE, sdl.K_DELETE, sdl.K_HOME, sdl.K_END:
+		return true
+	}
+	// Printable keys use their character as the keycode; the rest carry the scancode mask
+	code := int(key)
+	return code >= 0x20 && code != 0x7f && code&(1<<30) == 0
+}
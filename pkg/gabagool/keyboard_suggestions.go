@@ -0,0 +1,199 @@
+package gabagool
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// SuggestionProvider returns completions for the text before the cursor.
+// An accepted suggestion replaces the word before the cursor. Suggestions may
+// repeat the text before that word, which is then left as typed, so providers can
+// return either single words or completions of the whole input.
+// Providers run off the UI thread and may block (e.g. to query a database).
+type SuggestionProvider func(prefix string) []string
+
+const maxSuggestions = 8
+
+var keyboardSuggestionHelpLine = "• L2 / R2: Next suggestion / accept suggestion"
+
+// StaticSuggestions completes the last word of the input from a fixed word list.
+// Matching is case-insensitive and keeps the word list order.
+func StaticSuggestions(words []string) SuggestionProvider {
+	return func(prefix string) []string {
+		return internal.CompleteWord(words, prefix, maxSuggestions)
+	}
+}
+
+// RecentSuggestions completes the whole input from previously entered values,
+// most recent first. With an empty input all entries are offered.
+func RecentSuggestions(entries []string) SuggestionProvider {
+	return func(prefix string) []string {
+		return internal.CompleteEntry(entries, prefix, maxSuggestions)
+	}
+}
+
+// keyboardSuggestions tracks the suggestion strip. Queries run in goroutines and only
+// the result of the latest query is kept.
+type keyboardSuggestions struct {
+	provider SuggestionProvider
+	rect     sdl.Rect
+
+	mu         sync.Mutex
+	generation int
+	results    []string
+	ready      bool
+
+	query    string
+	queried  bool
+	items    []string
+	selected int
+}
+
+// enableSuggestions attaches a provider and makes room for the strip above the keys.
+func (kb *virtualKeyboard) enableSuggestions(provider SuggestionProvider) {
+	if provider == nil {
+		return
+	}
+
+	kb.suggestions = &keyboardSuggestions{provider: provider}
+	kb.reserveSuggestionStrip()
+
+	if kb.helpOverlay != nil {
+		kb.helpOverlay.Lines = append(append([]string(nil), kb.helpOverlay.Lines...), keyboardSuggestionHelpLine)
+	}
+}
+
+// reserveSuggestionStrip squeezes the key rects downwards to free a strip at the
+// top of the keyboard area.
+func (kb *virtualKeyboard) reserveSuggestionStrip() {
+	if kb.suggestions == nil {
+		return
+	}
+
+	area := kb.KeyboardRect
+	stripHeight := area.H / 8
+	spacing := int32(6)
+	kb.squeezeKeys(area.Y + stripHeight + spacing)
+
+	kb.suggestions.rect = sdl.Rect{X: area.X, Y: area.Y, W: area.W, H: stripHeight}
+}
+
+// updateSuggestions starts a query when the text before the cursor changed and
+// picks up finished results.
+func (kb *virtualKeyboard) updateSuggestions() {
+	s := kb.suggestions
+	if s == nil {
+		return
+	}
+
+	query := string([]rune(kb.TextBuffer)[:kb.CursorPosition])
+	if !s.queried || query != s.query {
+		s.query = query
+		s.queried = true
+
+		s.mu.Lock()
+		s.generation++
+		generation := s.generation
+		s.mu.Unlock()
+
+		go func() {
+			results := s.provider(query)
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if generation == s.generation {
+				s.results = results
+				s.ready = true
+			}
+		}()
+	}
+
+	s.mu.Lock()
+	if s.ready {
+		s.items = s.results
+		s.selected = 0
+		s.ready = false
+	}
+	s.mu.Unlock()
+}
+
+func (kb *virtualKeyboard) cycleSuggestion() {
+	s := kb.suggestions
+	if s == nil || len(s.items) == 0 {
+		return
+	}
+	s.selected = (s.selected + 1) % len(s.items)
+}
+
+// acceptSuggestion replaces the word before the cursor with the highlighted suggestion.
+// A suggestion that would break MaxLength or AllowedRunes leaves the text unchanged.
+func (kb *virtualKeyboard) acceptSuggestion() {
+	s := kb.suggestions
+	if s == nil || len(s.items) == 0 {
+		return
+	}
+
+	kb.commitComposition()
+
+	textRunes := []rune(kb.TextBuffer)
+	before := string(textRunes[:kb.CursorPosition])
+	start := utf8.RuneCountInString(before[:internal.LastWordStart(before)])
+	word := internal.SuggestionWord(before, s.items[s.selected])
+
+	text := string(textRunes[:start]) + word + string(textRunes[kb.CursorPosition:])
+	if !kb.fitsOptions(text) {
+		return
+	}
+
+	kb.TextBuffer = text
+	kb.CursorPosition = start + utf8.RuneCountInString(word)
+	kb.selectionAnchor = -1
+	s.items = nil
+}
+
+func (kb *virtualKeyboard) renderSuggestions(renderer *sdl.Renderer) {
+	s := kb.suggestions
+	if s == nil || len(s.items) == 0 {
+		return
+	}
+
+	font := internal.Fonts.SmallFont
+	padding := int32(12)
+	spacing := int32(6)
+	x := s.rect.X
+	right := s.rect.X + s.rect.W
+
+	for i, item := range s.items {
+		// Only show the word the suggestion puts in place of the one being typed
+		label := internal.SuggestionWord(s.query, item)
+		if label == "" {
+			label = item
+		}
+
+		textWidth, _, err := font.SizeUTF8(label)
+		if err != nil {
+			continue
+		}
+		chipWidth := int32(textWidth) + padding*2
+		if x+chipWidth > right {
+			if i == 0 {
+				chipWidth = right - x
+			} else {
+				break
+			}
+		}
+
+		chip := sdl.Rect{X: x, Y: s.rect.Y, W: chipWidth, H: s.rect.H}
+		bgColor := sdl.Color{R: 50, G: 50, B: 60, A: 255}
+		if i == s.selected {
+			bgColor = sdl.Color{R: 100, G: 100, B: 240, A: 255}
+		}
+		internal.DrawRoundedRect(renderer, &chip, s.rect.H/4, bgColor)
+
+		kb.renderKeyText(renderer, font, label, chip)
+		x += chipWidth + spacing
+	}
+}
@@ -0,0 +1,76 @@
+package gabagool
+
+import "testing"
+
+func TestAcceptSuggestion(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		cursor     int
+		suggestion string
+		options    KeyboardOptions
+		wantText   string
+		wantCursor int
+	}{
+		{
+			name:       "completes the last word only",
+			text:       "super mar",
+			cursor:     9,
+			suggestion: "super Mario",
+			wantText:   "super Mario",
+			wantCursor: 11,
+		},
+		{
+			name:       "single word suggestion keeps earlier words",
+			text:       "super mar",
+			cursor:     9,
+			suggestion: "Mario",
+			wantText:   "super Mario",
+			wantCursor: 11,
+		},
+		{
+			name:       "text after the cursor is kept",
+			text:       "new mar world",
+			cursor:     7,
+			suggestion: "Mario",
+			wantText:   "new Mario world",
+			wantCursor: 9,
+		},
+		{
+			name:       "too long for MaxLength",
+			text:       "super mar",
+			cursor:     9,
+			suggestion: "Mario",
+			options:    KeyboardOptions{MaxLength: 10},
+			wantText:   "super mar",
+			wantCursor: 9,
+		},
+		{
+			name:       "rejected by AllowedRunes",
+			text:       "ab",
+			cursor:     2,
+			suggestion: "ab-c",
+			options:    KeyboardOptions{AllowedRunes: AllowRunes("abc")},
+			wantText:   "ab",
+			wantCursor: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kb := &virtualKeyboard{
+				TextBuffer:      tt.text,
+				CursorPosition:  tt.cursor,
+				options:         tt.options,
+				selectionAnchor: -1,
+				suggestions:     &keyboardSuggestions{items: []string{tt.suggestion}},
+			}
+
+			kb.acceptSuggestion()
+
+			if kb.TextBuffer != tt.wantText || kb.CursorPosition != tt.wantCursor {
+				t.Errorf("got %q with cursor %d, want %q with cursor %d", kb.TextBuffer, kb.CursorPosition, tt.wantText, tt.wantCursor)
+			}
+		})
+	}
+}
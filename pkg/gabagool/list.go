@@ -22,6 +22,7 @@ type ListOptions struct {
 	ShowImages bool // Display item images on the right side
 
 	InitialMultiSelectMode bool // Start in multi-select mode
+	AllowEmptySelection    bool // Allow confirming multi-select with nothing selected
 	DisableBackButton      bool // Prevent B button from closing the list
 
 	HelpTitle    string   // Title for the help overlay
@@ -402,6 +403,10 @@ func (lc *listController) handleActionButtons(button constants.VirtualButton, ru
 				result.Action = ListActionSelected
 				result.Selected = indices
 				result.VisiblePosition = indices[0] - lc.Options.VisibleStartIndex
+			} else if lc.Options.AllowEmptySelection {
+				*running = false
+				result.Action = ListActionSelected
+				result.Selected = []int{}
 			}
 		}
 	}
@@ -750,7 +755,7 @@ func (lc *listController) renderContent(window *internal.Window, visibleItems []
 	// Filter footer items: hide confirm button when multiselect is active with no selections
 	footerItems := lc.Options.FooterHelpItems
 	centerSingleItem := len(lc.Options.FooterHelpItems) == 1
-	if lc.MultiSelect && len(lc.SelectedItems) == 0 {
+	if lc.MultiSelect && len(lc.SelectedItems) == 0 && !lc.Options.AllowEmptySelection {
 		footerItems = lc.filterConfirmButton(lc.Options.FooterHelpItems)
 	}
 
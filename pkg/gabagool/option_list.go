@@ -19,19 +19,33 @@ const (
 	OptionTypeKeyboard
 	OptionTypeClickable
 	OptionTypeColorPicker // New option type for the color picker
+	OptionTypeDate
+	OptionTypeTime // Uses a 24-hour clock unless TimeFormat is set; TimeFormatLocale follows the current language
+	OptionTypeDuration
+	OptionTypeMultiSelect
 )
 
 // Option represents a single option for a menu item.
 // DisplayName is the text that will be displayed to the user.
 // Value is the value that will be returned when the option is submitted.
-// Type controls the option's behavior. There are four types:
+// Type controls the option's behavior. There are eight types:
 //   - Standard: A standard option that will be displayed to the user.
 //   - Keyboard: A keyboard option that will be displayed to the user.
 //   - Clickable: A clickable option that will be displayed to the user.
 //   - ColorPicker: A hexagonal color picker for selecting colors.
+//   - Date, Time, Duration: A segmented spinner picker for dates, times of day and durations.
+//   - MultiSelect: One choice in a checklist; the item's Options together form the set of choices.
 //
 // KeyboardPrompt is the text that will be displayed to the user when the option is a keyboard option.
-// For ColorPicker type, Value should be an sdl.Color.
+// For ColorPicker type, Value should be an sdl.Color. Set AdvancedColorPicker to open the full
+// color editor instead of the quick-pick grid; ColorPalette and ColorAllowAlpha configure it.
+// For Date and Time types, Value should be a time.Time; for Duration, a time.Duration. The
+// item's Value() returns these unformatted.
+// If DisplayName is empty these values are formatted for the current language.
+// TimeFormat and ShowSeconds configure the Time and Duration pickers. Time options use a
+// 24-hour clock unless TimeFormat is set; set it to TimeFormatLocale for locale-aware times.
+// For MultiSelect type, Selected marks the choice as checked. The item's Value() is a []interface{}
+// of the checked values, and the first option's OnUpdate receives that slice when the set changes.
 type Option struct {
 	DisplayName    string
 	Value          interface{}
@@ -40,7 +54,17 @@ type Option struct {
 	KeyboardLayout KeyboardLayout // Layout to use for keyboard input (default: KeyboardLayoutGeneral)
 	URLShortcuts   []URLShortcut  // Custom shortcuts for URL keyboard (up to 10, only used when KeyboardLayout is KeyboardLayoutURL)
 	Masked         bool
-	OnUpdate       func(newValue interface{})
+	TimeFormat     TimeFormat // Clock used by Time options (default: TimeFormat24Hour)
+	ShowSeconds    bool       // Adds a seconds field to Time and Duration options
+	Selected       bool       // Whether a MultiSelect choice is checked
+
+	AdvancedColorPicker bool        // ColorPicker options open the full color editor
+	ColorPalette        []sdl.Color // Swatches for the color editor's quick-pick tab
+	ColorAllowAlpha     bool        // Adds an alpha channel to the color editor
+
+	KeyboardPlaceholder string // Shown while a Keyboard option's text field is empty
+
+	OnUpdate func(newValue interface{})
 }
 
 type OptionListSettings struct {
@@ -65,16 +89,38 @@ type OptionListSettings struct {
 // If nil, the item is always visible.
 // VisibleWhen is an atomic bool that can be toggled dynamically (e.g., by another option's OnUpdate).
 // If set, it takes precedence over Visible.
+// Description is optional text shown in a panel at the bottom of the screen while the item is focused.
+// IsHeader marks the item as a non-selectable group header. Header rows are skipped during
+// navigation and their Options are ignored; use NewOptionsGroupHeader to create one.
 type ItemWithOptions struct {
 	Item           MenuItem
 	Options        []Option
 	SelectedOption int
 	Visible        func() bool  // nil = always visible
 	VisibleWhen    *atomic.Bool // if set, takes precedence over Visible
+	Description    string       // shown below the list while this item is focused
+	IsHeader       bool         // non-selectable group header row
 	colorPicker    *ColorPicker
 }
 
+// NewOptionsGroupHeader creates a non-selectable header row that titles the group of items following it.
+func NewOptionsGroupHeader(title string) ItemWithOptions {
+	return ItemWithOptions{
+		Item:     MenuItem{Text: title},
+		IsHeader: true,
+	}
+}
+
 func (iow *ItemWithOptions) Value() interface{} {
+	if iow.isMultiSelect() {
+		return iow.selectedValues()
+	}
+
+	switch iow.Options[iow.SelectedOption].Type {
+	case OptionTypeDate, OptionTypeTime, OptionTypeDuration:
+		return iow.Options[iow.SelectedOption].Value
+	}
+
 	if iow.Options[iow.SelectedOption].Value == nil {
 		return ""
 	}
@@ -82,6 +128,28 @@ func (iow *ItemWithOptions) Value() interface{} {
 	return fmt.Sprintf("%s", iow.Options[iow.SelectedOption].Value)
 }
 
+// setKeyboardText replaces the keyboard option at index with the entered text, selects it
+// and passes the text to its OnUpdate.
+func (iow *ItemWithOptions) setKeyboardText(index int, text, prompt string) {
+	o := iow.Options[index]
+	iow.Options[index] = Option{
+		DisplayName:         text,
+		Value:               text,
+		Type:                OptionTypeKeyboard,
+		KeyboardPrompt:      prompt,
+		KeyboardLayout:      o.KeyboardLayout,
+		URLShortcuts:        o.URLShortcuts,
+		Masked:              o.Masked,
+		OnUpdate:            o.OnUpdate,
+		KeyboardPlaceholder: o.KeyboardPlaceholder,
+	}
+	iow.SelectedOption = index
+
+	if o.OnUpdate != nil {
+		o.OnUpdate(text)
+	}
+}
+
 // IsVisible returns whether the item should be displayed.
 // If VisibleWhen is set, it takes precedence.
 // Otherwise, returns true if Visible is nil or if Visible() returns true.
@@ -95,6 +163,48 @@ func (iow *ItemWithOptions) IsVisible() bool {
 	return iow.Visible()
 }
 
+// isMultiSelect returns whether the item's options form a checklist.
+func (iow *ItemWithOptions) isMultiSelect() bool {
+	return len(iow.Options) > 0 && iow.Options[0].Type == OptionTypeMultiSelect
+}
+
+// selectedValues returns the values of the checked choices of a multi-select item.
+func (iow *ItemWithOptions) selectedValues() []interface{} {
+	values := make([]interface{}, 0, len(iow.Options))
+	for _, opt := range iow.Options {
+		if opt.Selected {
+			values = append(values, opt.Value)
+		}
+	}
+	return values
+}
+
+// multiSelectSummary returns the inline text shown for a multi-select item.
+func (iow *ItemWithOptions) multiSelectSummary() string {
+	count := 0
+	last := ""
+	for _, opt := range iow.Options {
+		if opt.Selected {
+			count++
+			last = opt.DisplayName
+		}
+	}
+
+	switch count {
+	case 0:
+		return "None"
+	case 1:
+		return last
+	default:
+		return fmt.Sprintf("%d selected", count)
+	}
+}
+
+// isSelectable returns whether the item can receive focus.
+func (iow *ItemWithOptions) isSelectable() bool {
+	return !iow.IsHeader && iow.IsVisible()
+}
+
 // OptionsListResult represents the return value of the OptionsList function.
 // Items is the entire list of menu items.
 // Selected is the index of the selected item.
@@ -142,10 +252,11 @@ type optionsListController struct {
 	helpOverlay *helpOverlay
 	ShowingHelp bool
 
-	itemScrollData        map[int]*internal.TextScrollData
-	optionValueScrollData map[int]*internal.TextScrollData
-	showingColorPicker    bool
-	activeColorPickerIdx  int
+	itemScrollData         map[int]*internal.TextScrollData
+	optionValueScrollData  map[int]*internal.TextScrollData
+	showingColorPicker     bool
+	activeColorPickerIdx   int
+	descriptionPanelHeight int32
 
 	directionalInput internal.DirectionalInput
 }
@@ -177,10 +288,10 @@ func newOptionsListController(title string, items []ItemWithOptions) *optionsLis
 		}
 	}
 
-	// Ensure selected item is visible; if not, find first visible item
-	if len(items) > 0 && !items[selectedIndex].IsVisible() {
+	// Ensure selected item is selectable; if not, find first selectable item
+	if len(items) > 0 && !items[selectedIndex].isSelectable() {
 		for i := range items {
-			if items[i].IsVisible() {
+			if items[i].isSelectable() {
 				selectedIndex = i
 				break
 			}
@@ -258,6 +369,7 @@ func OptionsList(title string, listOptions OptionListSettings, items []ItemWithO
 
 	optionsListController := newOptionsListController(title, items)
 
+	optionsListController.descriptionPanelHeight = optionsListController.calculateDescriptionPanelHeight(window)
 	optionsListController.MaxVisibleItems = int(optionsListController.calculateMaxVisibleItems(window))
 	optionsListController.Settings.FooterHelpItems = listOptions.FooterHelpItems
 	optionsListController.Settings.DisableBackButton = listOptions.DisableBackButton
@@ -273,7 +385,8 @@ func OptionsList(title string, listOptions OptionListSettings, items []ItemWithO
 		optionsListController.Settings.ConfirmButton = listOptions.ConfirmButton
 	}
 
-	if listOptions.InitialSelectedIndex > 0 && listOptions.InitialSelectedIndex < len(items) {
+	if listOptions.InitialSelectedIndex > 0 && listOptions.InitialSelectedIndex < len(items) &&
+		!items[listOptions.InitialSelectedIndex].IsHeader {
 		if optionsListController.SelectedIndex >= 0 && optionsListController.SelectedIndex < len(items) {
 			optionsListController.Items[optionsListController.SelectedIndex].Item.Selected = false
 		}
@@ -375,7 +488,7 @@ func (olc *optionsListController) calculateMaxVisibleItems(window *internal.Wind
 
 	footerHeight := int32(float32(50) * scaleFactor)
 
-	availableHeight := screenHeight - titleHeight - footerHeight - olc.StartY
+	availableHeight := screenHeight - titleHeight - footerHeight - olc.StartY - olc.descriptionPanelHeight
 
 	maxItems := availableHeight / itemSpacing
 
@@ -581,9 +694,17 @@ func (olc *optionsListController) handleDirectionalRepeats() {
 	}
 }
 
+// selectedItem returns the selected item, or nil when no selectable item is selected,
+// as in a list made only of group headers.
+func (olc *optionsListController) selectedItem() *ItemWithOptions {
+	if olc.SelectedIndex < 0 || olc.SelectedIndex >= len(olc.Items) || !olc.Items[olc.SelectedIndex].isSelectable() {
+		return nil
+	}
+	return &olc.Items[olc.SelectedIndex]
+}
+
 func (olc *optionsListController) handleAButton(running *bool, result *OptionsListResult) {
-	if olc.SelectedIndex >= 0 && olc.SelectedIndex < len(olc.Items) {
-		item := &olc.Items[olc.SelectedIndex]
+	if item := olc.selectedItem(); item != nil {
 		if len(item.Options) > 0 && item.SelectedOption < len(item.Options) {
 			o := item.Options[item.SelectedOption]
 			switch o.Type {
@@ -596,25 +717,30 @@ func (olc *optionsListController) handleAButton(running *bool, result *OptionsLi
 				if o.KeyboardLayout == KeyboardLayoutURL && len(o.URLShortcuts) > 0 {
 					keyboardResult, err = URLKeyboard(prompt, olc.Settings.HelpExitText, URLKeyboardConfig{
 						Shortcuts: o.URLShortcuts,
+						Options:   KeyboardOptions{Masked: o.Masked, Placeholder: o.KeyboardPlaceholder},
 					})
 				} else {
-					keyboardResult, err = Keyboard(prompt, olc.Settings.HelpExitText, o.KeyboardLayout)
+					keyboardResult, err = KeyboardWithOptions(prompt, olc.Settings.HelpExitText, KeyboardOptions{
+						Layout:      o.KeyboardLayout,
+						Masked:      o.Masked,
+						Placeholder: o.KeyboardPlaceholder,
+					})
 				}
 
 				if err == nil {
 					enteredText := keyboardResult.Text
-					item.Options[item.SelectedOption] = Option{
-						DisplayName:    enteredText,
-						Value:          enteredText,
-						Type:           OptionTypeKeyboard,
-						KeyboardPrompt: enteredText,
-						KeyboardLayout: o.KeyboardLayout,
-						URLShortcuts:   o.URLShortcuts,
-						Masked:         o.Masked,
-					}
+					item.setKeyboardText(item.SelectedOption, enteredText, enteredText)
 				}
 			case OptionTypeColorPicker:
-				olc.showColorPicker(olc.SelectedIndex)
+				if o.AdvancedColorPicker {
+					olc.showColorEditor(olc.SelectedIndex)
+				} else {
+					olc.showColorPicker(olc.SelectedIndex)
+				}
+			case OptionTypeDate, OptionTypeTime, OptionTypeDuration:
+				olc.showDateTimePicker(olc.SelectedIndex)
+			case OptionTypeMultiSelect:
+				olc.showMultiSelectPicker(olc.SelectedIndex)
 			case OptionTypeClickable:
 				*running = false
 				result.Action = ListActionSelected
@@ -629,6 +755,123 @@ func (olc *optionsListController) handleAButton(running *bool, result *OptionsLi
 	}
 }
 
+// showDateTimePicker opens the spinner picker matching the selected option's type
+// and stores the chosen value back on the option.
+func (olc *optionsListController) showDateTimePicker(itemIndex int) {
+	item := &olc.Items[itemIndex]
+	o := &item.Options[item.SelectedOption]
+
+	settings := DateTimePickerSettings{
+		TimeFormat:  o.TimeFormat,
+		ShowSeconds: o.ShowSeconds,
+		StatusBar:   olc.Settings.StatusBar,
+	}
+
+	var newValue interface{}
+	switch o.Type {
+	case OptionTypeDate:
+		initial, _ := o.Value.(time.Time)
+		res, err := DatePicker(item.Item.Text, initial, settings)
+		if err != nil {
+			return
+		}
+		newValue = res.Date
+	case OptionTypeTime:
+		initial, _ := o.Value.(time.Time)
+		res, err := TimePicker(item.Item.Text, initial, settings)
+		if err != nil {
+			return
+		}
+		newValue = res.Time
+	case OptionTypeDuration:
+		initial, _ := o.Value.(time.Duration)
+		res, err := DurationPicker(item.Item.Text, initial, settings)
+		if err != nil {
+			return
+		}
+		newValue = res.Duration
+	default:
+		return
+	}
+
+	o.Value = newValue
+	o.DisplayName = ""
+	delete(olc.optionValueScrollData, itemIndex)
+
+	if o.OnUpdate != nil {
+		o.OnUpdate(newValue)
+	}
+}
+
+// showMultiSelectPicker opens a checklist of the item's choices and stores the
+// checked state back on each option.
+func (olc *optionsListController) showMultiSelectPicker(itemIndex int) {
+	item := &olc.Items[itemIndex]
+
+	menuItems := make([]MenuItem, len(item.Options))
+	for i, opt := range item.Options {
+		menuItems[i] = MenuItem{
+			Text:     opt.DisplayName,
+			Selected: opt.Selected,
+			Metadata: i,
+		}
+	}
+
+	listOpts := DefaultListOptions(item.Item.Text, menuItems)
+	listOpts.UseSmallTitle = true
+	listOpts.InitialMultiSelectMode = true
+	listOpts.AllowEmptySelection = true
+	listOpts.StatusBar = olc.Settings.StatusBar
+	listOpts.FooterHelpItems = []FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "A", HelpText: "Toggle"},
+		{ButtonName: "Start", HelpText: "Confirm", IsConfirmButton: true},
+	}
+
+	listResult, err := List(listOpts)
+	if err != nil {
+		return
+	}
+
+	checked := make(map[int]bool, len(listResult.Selected))
+	for _, idx := range listResult.Selected {
+		checked[idx] = true
+	}
+
+	changed := false
+	for i := range item.Options {
+		if item.Options[i].Selected != checked[i] {
+			item.Options[i].Selected = checked[i]
+			changed = true
+		}
+	}
+
+	delete(olc.optionValueScrollData, itemIndex)
+
+	if changed && item.Options[0].OnUpdate != nil {
+		item.Options[0].OnUpdate(item.selectedValues())
+	}
+}
+
+// dateTimeDisplayText formats the value of a Date, Time or Duration option.
+func dateTimeDisplayText(o Option) string {
+	if o.DisplayName != "" {
+		return o.DisplayName
+	}
+
+	switch v := o.Value.(type) {
+	case time.Time:
+		if o.Type == OptionTypeTime {
+			return formatTimeOfDay(v, o.TimeFormat, o.ShowSeconds)
+		}
+		return formatDate(v)
+	case time.Duration:
+		return formatDuration(v, o.ShowSeconds)
+	}
+
+	return ""
+}
+
 func (olc *optionsListController) moveSelection(direction int) {
 	if len(olc.Items) == 0 {
 		return
@@ -656,12 +899,12 @@ func (olc *optionsListController) moveSelection(direction int) {
 			}
 		}
 
-		// If item is visible, we found our target
-		if olc.Items[olc.SelectedIndex].IsVisible() {
+		// If item can take focus, we found our target
+		if olc.Items[olc.SelectedIndex].isSelectable() {
 			break
 		}
 
-		// If we've wrapped around to start, no visible items exist
+		// If we've wrapped around to start, no selectable items exist
 		if olc.SelectedIndex == startIndex {
 			break
 		}
@@ -688,6 +931,45 @@ func (olc *optionsListController) showColorPicker(itemIndex int) {
 	}
 }
 
+// showColorEditor opens the full color editor for the selected option. OnUpdate
+// receives every intermediate color, and the original color again on cancel.
+func (olc *optionsListController) showColorEditor(itemIndex int) {
+	item := &olc.Items[itemIndex]
+	o := &item.Options[item.SelectedOption]
+
+	initial, ok := o.Value.(sdl.Color)
+	if !ok {
+		initial = sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	}
+
+	settings := ColorEditorSettings{
+		Palette:    o.ColorPalette,
+		AllowAlpha: o.ColorAllowAlpha,
+		StatusBar:  olc.Settings.StatusBar,
+	}
+	if onUpdate := o.OnUpdate; onUpdate != nil {
+		settings.OnChange = func(color sdl.Color) {
+			onUpdate(color)
+		}
+	}
+
+	res, err := ColorEditor(item.Item.Text, initial, settings)
+	if err != nil {
+		if o.OnUpdate != nil {
+			o.OnUpdate(initial)
+		}
+		return
+	}
+
+	o.Value = res.Color
+	o.DisplayName = internal.FormatHexColor(res.Color, o.ColorAllowAlpha)
+	delete(olc.optionValueScrollData, itemIndex)
+
+	if o.OnUpdate != nil {
+		o.OnUpdate(res.Color)
+	}
+}
+
 func (olc *optionsListController) hideColorPicker() {
 	if olc.activeColorPickerIdx >= 0 && olc.activeColorPickerIdx < len(olc.Items) {
 		item := &olc.Items[olc.activeColorPickerIdx]
@@ -700,9 +982,8 @@ func (olc *optionsListController) hideColorPicker() {
 }
 
 func (olc *optionsListController) showListPicker() {
-	item := &olc.Items[olc.SelectedIndex]
-
-	if len(item.Options) <= 1 {
+	item := olc.selectedItem()
+	if item == nil || len(item.Options) <= 1 {
 		return
 	}
 
@@ -750,28 +1031,18 @@ func (olc *optionsListController) showListPicker() {
 				if selectedOpt.KeyboardLayout == KeyboardLayoutURL && len(selectedOpt.URLShortcuts) > 0 {
 					keyboardResult, kbErr = URLKeyboard(prompt, olc.Settings.HelpExitText, URLKeyboardConfig{
 						Shortcuts: selectedOpt.URLShortcuts,
+						Options:   KeyboardOptions{Masked: selectedOpt.Masked, Placeholder: selectedOpt.KeyboardPlaceholder},
 					})
 				} else {
-					keyboardResult, kbErr = Keyboard(prompt, olc.Settings.HelpExitText, selectedOpt.KeyboardLayout)
+					keyboardResult, kbErr = KeyboardWithOptions(prompt, olc.Settings.HelpExitText, KeyboardOptions{
+						Layout:      selectedOpt.KeyboardLayout,
+						Masked:      selectedOpt.Masked,
+						Placeholder: selectedOpt.KeyboardPlaceholder,
+					})
 				}
 
 				if kbErr == nil && keyboardResult.Text != "" {
-					enteredText := keyboardResult.Text
-					item.Options[newIndex] = Option{
-						DisplayName:    enteredText,
-						Value:          enteredText,
-						Type:           OptionTypeKeyboard,
-						KeyboardPrompt: selectedOpt.KeyboardPrompt,
-						KeyboardLayout: selectedOpt.KeyboardLayout,
-						URLShortcuts:   selectedOpt.URLShortcuts,
-						Masked:         selectedOpt.Masked,
-						OnUpdate:       selectedOpt.OnUpdate,
-					}
-					item.SelectedOption = newIndex
-
-					if selectedOpt.OnUpdate != nil {
-						selectedOpt.OnUpdate(enteredText)
-					}
+					item.setKeyboardText(newIndex, keyboardResult.Text, selectedOpt.KeyboardPrompt)
 				}
 				return
 			}
@@ -786,16 +1057,12 @@ func (olc *optionsListController) showListPicker() {
 }
 
 func (olc *optionsListController) cycleOptionLeft() {
-	if olc.SelectedIndex < 0 || olc.SelectedIndex >= len(olc.Items) {
+	item := olc.selectedItem()
+	if item == nil || len(item.Options) == 0 {
 		return
 	}
 
-	item := &olc.Items[olc.SelectedIndex]
-	if len(item.Options) == 0 {
-		return
-	}
-
-	if item.Options[item.SelectedOption].Type == OptionTypeClickable {
+	if item.Options[item.SelectedOption].Type == OptionTypeClickable || item.isMultiSelect() {
 		return
 	}
 
@@ -814,16 +1081,12 @@ func (olc *optionsListController) cycleOptionLeft() {
 }
 
 func (olc *optionsListController) cycleOptionRight() {
-	if olc.SelectedIndex < 0 || olc.SelectedIndex >= len(olc.Items) {
-		return
-	}
-
-	item := &olc.Items[olc.SelectedIndex]
-	if len(item.Options) == 0 {
+	item := olc.selectedItem()
+	if item == nil || len(item.Options) == 0 {
 		return
 	}
 
-	if item.Options[item.SelectedOption].Type == OptionTypeClickable {
+	if item.Options[item.SelectedOption].Type == OptionTypeClickable || item.isMultiSelect() {
 		return
 	}
 
@@ -1009,6 +1272,12 @@ func (olc *optionsListController) render(renderer *sdl.Renderer) {
 			continue
 		}
 
+		if item.IsHeader {
+			olc.renderGroupHeader(renderer, item.Item.Text, olc.StartY+(int32(displayPosition)*itemSpacing)-5, selectionRectHeight)
+			displayPosition++
+			continue
+		}
+
 		textColor := internal.GetTheme().TextColor
 		bgColor := sdl.Color{R: 0, G: 0, B: 0, A: 0}
 
@@ -1135,6 +1404,10 @@ func (olc *optionsListController) render(renderer *sdl.Renderer) {
 				olc.renderOptionValue(renderer, font, indicatorText, textColor, itemIndex, item.Item.Selected, maxOptionWidth, rightEdgeX, selectionRectY, selectionRectHeight)
 			} else if selectedOption.Type == OptionTypeClickable {
 				olc.renderOptionValue(renderer, font, selectedOption.DisplayName, textColor, itemIndex, item.Item.Selected, maxOptionWidth, rightEdgeX, selectionRectY, selectionRectHeight)
+			} else if selectedOption.Type == OptionTypeMultiSelect {
+				olc.renderOptionValue(renderer, font, item.multiSelectSummary(), textColor, itemIndex, item.Item.Selected, maxOptionWidth, rightEdgeX, selectionRectY, selectionRectHeight)
+			} else if selectedOption.Type == OptionTypeDate || selectedOption.Type == OptionTypeTime || selectedOption.Type == OptionTypeDuration {
+				olc.renderOptionValue(renderer, font, dateTimeDisplayText(selectedOption), textColor, itemIndex, item.Item.Selected, maxOptionWidth, rightEdgeX, selectionRectY, selectionRectHeight)
 			} else if selectedOption.Type == OptionTypeColorPicker {
 				// For color picker option, display the color swatch and hex value
 				indicatorText := selectedOption.DisplayName
@@ -1204,6 +1477,8 @@ func (olc *optionsListController) render(renderer *sdl.Renderer) {
 		displayPosition++
 	}
 
+	olc.renderDescriptionPanel(renderer)
+
 	renderFooter(
 		renderer,
 		internal.Fonts.SmallFont,
@@ -1214,6 +1489,115 @@ func (olc *optionsListController) render(renderer *sdl.Renderer) {
 	)
 }
 
+// renderGroupHeader draws a section title for a header row, followed by a
+// divider line that runs to the right margin.
+func (olc *optionsListController) renderGroupHeader(renderer *sdl.Renderer, text string, rowY, rowHeight int32) {
+	window := internal.GetWindow()
+	font := internal.Fonts.TinyFont
+	theme := internal.GetTheme()
+
+	leftX := olc.Settings.Margins.Left
+	rightX := window.GetWidth() - olc.Settings.Margins.Right
+	lineY := rowY + rowHeight/2
+
+	if text != "" {
+		surface, _ := font.RenderUTF8Blended(text, theme.TextColor)
+		if surface != nil {
+			defer surface.Free()
+			texture, _ := renderer.CreateTextureFromSurface(surface)
+			if texture != nil {
+				defer texture.Destroy()
+
+				width := internal.Min32(surface.W, rightX-leftX)
+				renderer.Copy(texture, &sdl.Rect{X: 0, Y: 0, W: width, H: surface.H}, &sdl.Rect{
+					X: leftX,
+					Y: rowY + (rowHeight-surface.H)/2,
+					W: width,
+					H: surface.H,
+				})
+				leftX += width + int32(float32(15)*internal.GetScaleFactor())
+			}
+		}
+	}
+
+	if leftX < rightX {
+		renderer.SetDrawColor(80, 80, 80, 255)
+		renderer.DrawLine(leftX, lineY, rightX, lineY)
+	}
+}
+
+// calculateDescriptionPanelHeight returns the height reserved at the bottom of
+// the screen for item descriptions. The panel is sized for the longest
+// description so the list does not jump as focus moves, and is omitted
+// entirely when no item has a description.
+func (olc *optionsListController) calculateDescriptionPanelHeight(window *internal.Window) int32 {
+	font := internal.Fonts.TinyFont
+	maxWidth := olc.descriptionTextWidth(window)
+
+	var textHeight int32
+	var lineCount int32
+	for i := range olc.Items {
+		if olc.Items[i].IsHeader || olc.Items[i].Description == "" {
+			continue
+		}
+		h := internal.MultilineTextHeight(olc.Items[i].Description, font, maxWidth)
+		if h > textHeight {
+			textHeight = h
+			lineCount = h / int32(font.Height())
+		}
+	}
+
+	if textHeight == 0 {
+		return 0
+	}
+
+	// RenderMultilineText adds 5px between lines on top of the font height.
+	padding := int32(float32(12) * internal.GetScaleFactor())
+	return textHeight + lineCount*5 + padding*2
+}
+
+func (olc *optionsListController) descriptionTextWidth(window *internal.Window) int32 {
+	padding := int32(float32(12) * internal.GetScaleFactor())
+	return window.GetWidth() - olc.Settings.Margins.Left - olc.Settings.Margins.Right - padding*2
+}
+
+// renderDescriptionPanel draws the focused item's description in the space
+// reserved above the footer.
+func (olc *optionsListController) renderDescriptionPanel(renderer *sdl.Renderer) {
+	if olc.descriptionPanelHeight == 0 || olc.SelectedIndex < 0 || olc.SelectedIndex >= len(olc.Items) {
+		return
+	}
+
+	description := olc.Items[olc.SelectedIndex].Description
+	if description == "" {
+		return
+	}
+
+	scaleFactor := internal.GetScaleFactor()
+	window := internal.GetWindow()
+	padding := int32(float32(12) * scaleFactor)
+	footerHeight := int32(float32(50) * scaleFactor)
+
+	panelRect := &sdl.Rect{
+		X: olc.Settings.Margins.Left - 10,
+		Y: window.GetHeight() - olc.Settings.Margins.Bottom - footerHeight - olc.descriptionPanelHeight - 10,
+		W: window.GetWidth() - olc.Settings.Margins.Left - olc.Settings.Margins.Right + 20,
+		H: olc.descriptionPanelHeight,
+	}
+	internal.DrawRoundedRect(renderer, panelRect, int32(float32(12)*scaleFactor), sdl.Color{R: 0, G: 0, B: 0, A: 180})
+
+	internal.RenderMultilineText(
+		renderer,
+		description,
+		internal.Fonts.TinyFont,
+		olc.descriptionTextWidth(window),
+		olc.Settings.Margins.Left+padding,
+		panelRect.Y+padding,
+		internal.GetTheme().TextColor,
+		constants.TextAlignLeft,
+	)
+}
+
 func (olc *optionsListController) renderOptionValue(
 	renderer *sdl.Renderer,
 	font *ttf.Font,
@@ -1,8 +1,10 @@
 package nextui
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
 	"github.com/veandco/go-sdl2/sdl"
 )
 
@@ -85,6 +87,10 @@ func TestParseHexColor(t *testing.T) {
 }
 
 func TestResolveBackgroundColor(t *testing.T) {
+	// Invalid colors log a warning; keep the log file out of the package tree.
+	internal.SetLogPath(filepath.Join(t.TempDir(), "app.log"))
+	t.Cleanup(internal.CloseLogger)
+
 	tests := []struct {
 		name  string
 		input string
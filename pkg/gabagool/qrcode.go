@@ -0,0 +1,71 @@
+package gabagool
+
+import (
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// QRCodeErrorCorrection sets how much of a QR code can be damaged or obscured and
+// still scan. Higher levels make denser codes.
+type QRCodeErrorCorrection int
+
+const (
+	QRCodeErrorCorrectionMedium   QRCodeErrorCorrection = iota // Recovers ~15% (default)
+	QRCodeErrorCorrectionLow                                   // Recovers ~7%
+	QRCodeErrorCorrectionQuartile                              // Recovers ~25%
+	QRCodeErrorCorrectionHigh                                  // Recovers ~30%
+)
+
+func (e QRCodeErrorCorrection) internal() internal.QRErrorCorrection {
+	switch e {
+	case QRCodeErrorCorrectionLow:
+		return internal.QRErrorCorrectionLow
+	case QRCodeErrorCorrectionQuartile:
+		return internal.QRErrorCorrectionQuartile
+	case QRCodeErrorCorrectionHigh:
+		return internal.QRErrorCorrectionHigh
+	default:
+		return internal.QRErrorCorrectionMedium
+	}
+}
+
+// createQRCodeTexture encodes text as a QR code no larger than maxSize pixels square.
+// Modules are drawn at a whole number of pixels each, surrounded by the quiet zone,
+// so the code stays sharp at any size.
+func createQRCodeTexture(renderer *sdl.Renderer, text string, level QRCodeErrorCorrection, maxSize int32) (*sdl.Texture, sdl.Rect, error) {
+	code, err := internal.EncodeQR([]byte(text), level.internal())
+	if err != nil {
+		return nil, sdl.Rect{}, err
+	}
+
+	modules := int32(code.Size + internal.QRQuietZone*2)
+	scale := internal.Max32(maxSize/modules, 1)
+	side := modules * scale
+
+	surface, err := sdl.CreateRGBSurfaceWithFormat(0, side, side, 32, sdl.PIXELFORMAT_RGBA8888)
+	if err != nil {
+		return nil, sdl.Rect{}, err
+	}
+	defer surface.Free()
+
+	surface.FillRect(nil, sdl.MapRGBA(surface.Format, 255, 255, 255, 255))
+	dark := sdl.MapRGBA(surface.Format, 0, 0, 0, 255)
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if code.Dark(x, y) {
+				surface.FillRect(&sdl.Rect{
+					X: (int32(x) + internal.QRQuietZone) * scale,
+					Y: (int32(y) + internal.QRQuietZone) * scale,
+					W: scale,
+					H: scale,
+				}, dark)
+			}
+		}
+	}
+
+	texture, err := renderer.CreateTextureFromSurface(surface)
+	if err != nil {
+		return nil, sdl.Rect{}, err
+	}
+	return texture, sdl.Rect{W: side, H: side}, nil
+}
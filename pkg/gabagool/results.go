@@ -15,8 +15,9 @@ const (
 type DetailAction int
 
 const (
-	DetailActionNone      DetailAction = iota // No action taken
-	DetailActionTriggered                     // User triggered primary action (A button)
-	DetailActionConfirmed                     // User confirmed via action button
-	DetailActionCancelled                     // User cancelled/went back (B button)
+	DetailActionNone          DetailAction = iota // No action taken
+	DetailActionTriggered                         // User triggered primary action (A button)
+	DetailActionConfirmed                         // User confirmed via action button
+	DetailActionCancelled                         // User cancelled/went back (B button)
+	DetailActionButtonPressed                     // User activated a button section (see DetailScreenResult.ButtonID)
 )
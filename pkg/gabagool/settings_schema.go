@@ -0,0 +1,633 @@
+package gabagool
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/BurntSushi/toml"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// SchemaFormat identifies the encoding of a settings schema.
+type SchemaFormat int
+
+const (
+	SchemaFormatJSON SchemaFormat = iota
+	SchemaFormatTOML
+)
+
+// SchemaError describes a problem in a settings schema.
+// Line is the 1-based line the problem was found on, or 0 if it could not be determined.
+type SchemaError struct {
+	Line int
+	Msg  string
+	Err  error
+}
+
+func (e *SchemaError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("settings schema: line %d: %s", e.Line, e.Msg)
+	}
+	return fmt.Sprintf("settings schema: %s", e.Msg)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+type settingsSchema struct {
+	Items []settingsSchemaItem `json:"items" toml:"items"`
+}
+
+type settingsSchemaItem struct {
+	ID          string                   `json:"id" toml:"id"`
+	Label       string                   `json:"label" toml:"label"`
+	Header      string                   `json:"header" toml:"header"`
+	Type        string                   `json:"type" toml:"type"`
+	Description string                   `json:"description" toml:"description"`
+	Default     interface{}              `json:"default" toml:"default"`
+	Options     []settingsSchemaOption   `json:"options" toml:"options"`
+	Prompt      string                   `json:"prompt" toml:"prompt"`
+	Masked      bool                     `json:"masked" toml:"masked"`
+	ShowSeconds bool                     `json:"show_seconds" toml:"show_seconds"`
+	TimeFormat  string                   `json:"time_format" toml:"time_format"`
+	Advanced    bool                     `json:"advanced" toml:"advanced"`
+	Palette     []string                 `json:"palette" toml:"palette"`
+	AllowAlpha  bool                     `json:"allow_alpha" toml:"allow_alpha"`
+	VisibleWhen *settingsSchemaCondition `json:"visible_when" toml:"visible_when"`
+}
+
+type settingsSchemaOption struct {
+	Label string      `json:"label" toml:"label"`
+	Value interface{} `json:"value" toml:"value"`
+}
+
+type settingsSchemaCondition struct {
+	Setting   string        `json:"setting" toml:"setting"`
+	Equals    interface{}   `json:"equals" toml:"equals"`
+	NotEquals interface{}   `json:"not_equals" toml:"not_equals"`
+	In        []interface{} `json:"in" toml:"in"`
+}
+
+// LoadSettingsSchema reads a settings schema from a .json or .toml file and
+// builds the items for an OptionsList. See ParseSettingsSchema for the format.
+func LoadSettingsSchema(path string) ([]ItemWithOptions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ParseSettingsSchema(data, SchemaFormatJSON)
+	case ".toml":
+		return ParseSettingsSchema(data, SchemaFormatTOML)
+	}
+
+	return nil, &SchemaError{Msg: fmt.Sprintf("unsupported file extension %q", filepath.Ext(path))}
+}
+
+// ParseSettingsSchema builds the items for an OptionsList from a JSON or TOML schema.
+//
+// The schema is a list of items. Each item is either a group header or a setting:
+//
+//	{"items": [
+//	    {"header": "Sync"},
+//	    {"id": "sync", "label": "Auto Sync", "type": "toggle", "default": true,
+//	     "description": "Sync saves whenever the device wakes"},
+//	    {"id": "sync_time", "label": "Sync At", "type": "time", "default": "03:00",
+//	     "visible_when": {"setting": "sync", "equals": true}}
+//	]}
+//
+// Supported types are standard (the default when options are given), toggle, keyboard,
+// clickable, color, date (YYYY-MM-DD), time (HH:MM[:SS]), duration (e.g. "1h30m") and
+// multiselect. Standard and multiselect items list their choices under options, each with
+// a label and value. Whole numbers are decoded as int. Keyboard items take an optional
+// prompt, shown while the text is empty, and masked for passwords.
+//
+// visible_when hides an item unless another setting's value equals, does not equal or is
+// in a list of values. It is compiled into the item's VisibleWhen flag, which is kept up to
+// date through the options' OnUpdate callbacks; callbacks added afterwards should call the
+// existing OnUpdate. The setting's id is stored in Item.Metadata; see SettingsSchemaValues.
+//
+// Problems are reported as a *SchemaError with the line number of the offending item. In
+// TOML, items may be [[items]] tables or inline tables in an items = [...] array.
+func ParseSettingsSchema(data []byte, format SchemaFormat) ([]ItemWithOptions, error) {
+	var schema settingsSchema
+	var itemLines []int
+
+	switch format {
+	case SchemaFormatJSON:
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return nil, jsonSchemaError(data, err)
+		}
+		itemLines = jsonItemLines(data)
+	case SchemaFormatTOML:
+		if err := toml.Unmarshal(data, &schema); err != nil {
+			return nil, tomlSchemaError(err)
+		}
+		itemLines = tomlItemLines(data)
+	default:
+		return nil, &SchemaError{Msg: "unknown schema format"}
+	}
+
+	lineOf := func(index int) int {
+		if index < len(itemLines) {
+			return itemLines[index]
+		}
+		return 0
+	}
+
+	items := make([]ItemWithOptions, 0, len(schema.Items))
+	indexByID := make(map[string]int)
+
+	for i, raw := range schema.Items {
+		item, err := buildSchemaItem(raw)
+		if err != nil {
+			return nil, &SchemaError{Line: lineOf(i), Msg: err.Error()}
+		}
+
+		if raw.ID != "" {
+			if _, exists := indexByID[raw.ID]; exists {
+				return nil, &SchemaError{Line: lineOf(i), Msg: fmt.Sprintf("duplicate id %q", raw.ID)}
+			}
+			indexByID[raw.ID] = len(items)
+		}
+
+		items = append(items, item)
+	}
+
+	for i, raw := range schema.Items {
+		cond := raw.VisibleWhen
+		if cond == nil {
+			continue
+		}
+		if cond.Setting == "" {
+			return nil, &SchemaError{Line: lineOf(i), Msg: "visible_when requires a setting"}
+		}
+		if _, ok := indexByID[cond.Setting]; !ok {
+			return nil, &SchemaError{Line: lineOf(i), Msg: fmt.Sprintf("visible_when refers to unknown setting %q", cond.Setting)}
+		}
+		if cond.Equals == nil && cond.NotEquals == nil && len(cond.In) == 0 {
+			return nil, &SchemaError{Line: lineOf(i), Msg: "visible_when requires equals, not_equals or in"}
+		}
+		if cond.Setting == raw.ID {
+			return nil, &SchemaError{Line: lineOf(i), Msg: "visible_when cannot refer to its own setting"}
+		}
+	}
+
+	compileSchemaVisibility(items, schema.Items, indexByID)
+
+	return items, nil
+}
+
+// SettingsSchemaValues returns the current value of every item built from a settings
+// schema, keyed by its id. Multiselect settings map to a []interface{} of checked values.
+func SettingsSchemaValues(items []ItemWithOptions) map[string]interface{} {
+	values := make(map[string]interface{})
+	for i := range items {
+		id, ok := items[i].Item.Metadata.(string)
+		if !ok || id == "" || items[i].IsHeader {
+			continue
+		}
+		values[id] = schemaItemValue(&items[i])
+	}
+	return values
+}
+
+func schemaItemValue(item *ItemWithOptions) interface{} {
+	if len(item.Options) == 0 {
+		return nil
+	}
+	if item.isMultiSelect() {
+		return item.selectedValues()
+	}
+	return item.Options[item.SelectedOption].Value
+}
+
+func buildSchemaItem(raw settingsSchemaItem) (ItemWithOptions, error) {
+	if raw.Header != "" || raw.Type == "header" {
+		title := raw.Header
+		if title == "" {
+			title = raw.Label
+		}
+		return NewOptionsGroupHeader(title), nil
+	}
+
+	if raw.Label == "" {
+		return ItemWithOptions{}, errors.New("item is missing a label")
+	}
+
+	item := ItemWithOptions{
+		Item:        MenuItem{Text: raw.Label, Metadata: raw.ID},
+		Description: raw.Description,
+	}
+
+	itemType := raw.Type
+	if itemType == "" {
+		itemType = "standard"
+	}
+
+	switch itemType {
+	case "standard", "toggle":
+		choices := raw.Options
+		if len(choices) == 0 && itemType == "toggle" {
+			choices = []settingsSchemaOption{{Label: "Off", Value: false}, {Label: "On", Value: true}}
+		}
+		if len(choices) == 0 {
+			return ItemWithOptions{}, fmt.Errorf("%s setting %q has no options", itemType, raw.Label)
+		}
+		matched := false
+		for i, choice := range choices {
+			value := normalizeSchemaValue(choice.Value)
+			item.Options = append(item.Options, Option{DisplayName: choice.Label, Value: value, Type: OptionTypeStandard})
+			if raw.Default != nil && schemaValuesEqual(value, raw.Default) {
+				item.SelectedOption = i
+				matched = true
+			}
+		}
+		if raw.Default != nil && !matched {
+			return ItemWithOptions{}, fmt.Errorf("default %v of setting %q matches none of its options", normalizeSchemaValue(raw.Default), raw.Label)
+		}
+
+	case "keyboard":
+		text := ""
+		if raw.Default != nil {
+			text = fmt.Sprint(normalizeSchemaValue(raw.Default))
+		}
+		item.Options = []Option{{
+			DisplayName:         text,
+			Value:               text,
+			Type:                OptionTypeKeyboard,
+			KeyboardPrompt:      text,
+			KeyboardPlaceholder: raw.Prompt,
+			Masked:              raw.Masked,
+		}}
+
+	case "clickable":
+		display := ""
+		if raw.Default != nil {
+			display = fmt.Sprint(normalizeSchemaValue(raw.Default))
+		}
+		item.Options = []Option{{DisplayName: display, Value: raw.ID, Type: OptionTypeClickable}}
+
+	case "color":
+		color := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+		if s, ok := raw.Default.(string); ok {
+			parsed, err := internal.ParseHexColor(s)
+			if err != nil {
+				return ItemWithOptions{}, err
+			}
+			color = parsed
+		}
+		var palette []sdl.Color
+		for _, hex := range raw.Palette {
+			parsed, err := internal.ParseHexColor(hex)
+			if err != nil {
+				return ItemWithOptions{}, err
+			}
+			palette = append(palette, parsed)
+		}
+		item.Options = []Option{{
+			DisplayName:         internal.FormatHexColor(color, raw.AllowAlpha),
+			Value:               color,
+			Type:                OptionTypeColorPicker,
+			AdvancedColorPicker: raw.Advanced || len(palette) > 0 || raw.AllowAlpha,
+			ColorPalette:        palette,
+			ColorAllowAlpha:     raw.AllowAlpha,
+		}}
+
+	case "date", "time":
+		value := time.Now()
+		if s, ok := raw.Default.(string); ok && s != "" {
+			parsed, err := parseSchemaTime(itemType, s)
+			if err != nil {
+				return ItemWithOptions{}, err
+			}
+			value = parsed
+		}
+		optType := OptionTypeDate
+		if itemType == "time" {
+			optType = OptionTypeTime
+		}
+		timeFormat, err := parseSchemaTimeFormat(raw.TimeFormat)
+		if err != nil {
+			return ItemWithOptions{}, err
+		}
+		item.Options = []Option{{Value: value, Type: optType, TimeFormat: timeFormat, ShowSeconds: raw.ShowSeconds}}
+
+	case "duration":
+		var value time.Duration
+		switch d := normalizeSchemaValue(raw.Default).(type) {
+		case string:
+			parsed, err := time.ParseDuration(d)
+			if err != nil {
+				return ItemWithOptions{}, fmt.Errorf("invalid duration %q", d)
+			}
+			value = parsed
+		case int:
+			value = time.Duration(d) * time.Second
+		case nil:
+		default:
+			return ItemWithOptions{}, fmt.Errorf("invalid duration %v", d)
+		}
+		item.Options = []Option{{Value: value, Type: OptionTypeDuration, ShowSeconds: raw.ShowSeconds}}
+
+	case "multiselect":
+		if len(raw.Options) == 0 {
+			return ItemWithOptions{}, fmt.Errorf("multiselect setting %q has no options", raw.Label)
+		}
+		defaults, _ := raw.Default.([]interface{})
+		for _, choice := range raw.Options {
+			value := normalizeSchemaValue(choice.Value)
+			selected := false
+			for _, d := range defaults {
+				if schemaValuesEqual(value, d) {
+					selected = true
+				}
+			}
+			item.Options = append(item.Options, Option{DisplayName: choice.Label, Value: value, Type: OptionTypeMultiSelect, Selected: selected})
+		}
+
+	default:
+		return ItemWithOptions{}, fmt.Errorf("unknown type %q", raw.Type)
+	}
+
+	return item, nil
+}
+
+func parseSchemaTime(itemType, s string) (time.Time, error) {
+	layouts := []string{time.DateOnly}
+	if itemType == "time" {
+		layouts = []string{"15:04", time.TimeOnly}
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			if itemType == "time" {
+				now := time.Now()
+				t = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local)
+			}
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid %s %q", itemType, s)
+}
+
+func parseSchemaTimeFormat(s string) (TimeFormat, error) {
+	switch s {
+	case "", "24h":
+		return TimeFormat24Hour, nil
+	case "12h":
+		return TimeFormat12Hour, nil
+	case "locale":
+		return TimeFormatLocale, nil
+	}
+	return TimeFormat24Hour, fmt.Errorf("invalid time_format %q (expected 12h, 24h or locale)", s)
+}
+
+// compileSchemaVisibility turns visible_when conditions into VisibleWhen flags and
+// wraps the controlling settings' OnUpdate callbacks to keep those flags current.
+func compileSchemaVisibility(items []ItemWithOptions, raw []settingsSchemaItem, indexByID map[string]int) {
+	dependents := make(map[string][]int)
+
+	for i := range raw {
+		cond := raw[i].VisibleWhen
+		if cond == nil {
+			continue
+		}
+
+		controller := &items[indexByID[cond.Setting]]
+		items[i].VisibleWhen = &atomic.Bool{}
+		items[i].VisibleWhen.Store(cond.matches(schemaItemValue(controller)))
+		dependents[cond.Setting] = append(dependents[cond.Setting], i)
+	}
+
+	for id, indices := range dependents {
+		controller := &items[indexByID[id]]
+		for j := range controller.Options {
+			previous := controller.Options[j].OnUpdate
+			controller.Options[j].OnUpdate = func(newValue interface{}) {
+				for _, i := range indices {
+					items[i].VisibleWhen.Store(raw[i].VisibleWhen.matches(newValue))
+				}
+				if previous != nil {
+					previous(newValue)
+				}
+			}
+		}
+	}
+}
+
+// matches reports whether value satisfies the condition. For multiselect
+// settings the condition is checked against each checked value.
+func (c *settingsSchemaCondition) matches(value interface{}) bool {
+	if values, ok := value.([]interface{}); ok {
+		if c.NotEquals != nil {
+			for _, v := range values {
+				if schemaValuesEqual(v, c.NotEquals) {
+					return false
+				}
+			}
+			return true
+		}
+		for _, v := range values {
+			if c.matches(v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(c.In) > 0 {
+		for _, candidate := range c.In {
+			if schemaValuesEqual(value, candidate) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if c.Equals != nil {
+		return schemaValuesEqual(value, c.Equals)
+	}
+
+	return !schemaValuesEqual(value, c.NotEquals)
+}
+
+// normalizeSchemaValue makes JSON and TOML decode to the same Go types:
+// whole numbers become int regardless of format.
+func normalizeSchemaValue(v interface{}) interface{} {
+	switch n := v.(type) {
+	case float64:
+		if n == float64(int(n)) {
+			return int(n)
+		}
+	case int64:
+		return int(n)
+	}
+	return v
+}
+
+func schemaValuesEqual(a, b interface{}) bool {
+	a = normalizeSchemaValue(a)
+	b = normalizeSchemaValue(b)
+
+	if t, ok := a.(time.Time); ok {
+		return fmt.Sprint(t) == fmt.Sprint(b)
+	}
+
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func lineAtOffset(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+func jsonSchemaError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return &SchemaError{Line: lineAtOffset(data, syntaxErr.Offset), Msg: syntaxErr.Error(), Err: err}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		msg := fmt.Sprintf("%s should be %s, not %s", typeErr.Field, typeErr.Type, typeErr.Value)
+		return &SchemaError{Line: lineAtOffset(data, typeErr.Offset), Msg: msg, Err: err}
+	}
+
+	return &SchemaError{Msg: err.Error(), Err: err}
+}
+
+// jsonItemLines returns the line each element of the top-level "items" array starts on.
+func jsonItemLines(data []byte) []int {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+
+		if key, _ := tok.(string); key != "items" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil
+			}
+			continue
+		}
+
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+			return nil
+		}
+
+		var lines []int
+		for dec.More() {
+			offset := dec.InputOffset()
+			for offset < int64(len(data)) && strings.ContainsRune(" \t\r\n,", rune(data[offset])) {
+				offset++
+			}
+			lines = append(lines, lineAtOffset(data, offset))
+
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return lines
+			}
+		}
+		return lines
+	}
+
+	return nil
+}
+
+func tomlSchemaError(err error) error {
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) {
+		return &SchemaError{Line: parseErr.Position.Line, Msg: parseErr.Message, Err: err}
+	}
+
+	// Type mismatches are plain errors with the line only in the message
+	if m := tomlErrorLine.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return &SchemaError{Line: line, Msg: strings.TrimPrefix(err.Error(), m[0]), Err: err}
+	}
+	return &SchemaError{Msg: err.Error(), Err: err}
+}
+
+var (
+	tomlErrorLine        = regexp.MustCompile(`^toml: line (\d+) \(last key .*?\): `)
+	tomlItemHeader       = regexp.MustCompile(`^\s*\[\[\s*items\s*\]\]`)
+	tomlInlineItemsStart = regexp.MustCompile(`^\s*items\s*=\s*\[`)
+)
+
+// tomlItemLines returns the line each item starts on: its [[items]] table header, or
+// the opening brace of its inline table in an items = [...] array.
+func tomlItemLines(data []byte) []int {
+	lines := strings.Split(string(data), "\n")
+
+	var itemLines []int
+	for i, line := range lines {
+		if tomlItemHeader.MatchString(line) {
+			itemLines = append(itemLines, i+1)
+		} else if loc := tomlInlineItemsStart.FindStringIndex(line); loc != nil {
+			return append(itemLines, tomlInlineItemLines(lines, i, loc[1])...)
+		}
+	}
+	return itemLines
+}
+
+// tomlInlineItemLines scans an inline items array from just inside its opening bracket
+// at lines[row][col], and returns the line of each inline table directly in it.
+func tomlInlineItemLines(lines []string, row, col int) []int {
+	var itemLines []int
+	depth := 1
+	var quote byte
+
+	for ; row < len(lines); row, col = row+1, 0 {
+		line := lines[row]
+		for ; col < len(line); col++ {
+			c := line[col]
+			switch {
+			case quote != 0:
+				if c == '\\' && quote == '"' {
+					col++ // skip the escaped character
+				} else if c == quote {
+					quote = 0
+				}
+			case c == '"' || c == '\'':
+				quote = c
+			case c == '#':
+				col = len(line) // comment to the end of the line
+			case c == '[' || c == '{':
+				if c == '{' && depth == 1 {
+					itemLines = append(itemLines, row+1)
+				}
+				depth++
+			case c == ']' || c == '}':
+				depth--
+				if depth == 0 {
+					return itemLines
+				}
+			}
+		}
+	}
+	return itemLines
+}
@@ -0,0 +1,424 @@
+package gabagool
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSettingsSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format SchemaFormat
+		schema string
+		line   int
+		msg    string
+	}{
+		{
+			name:   "json syntax",
+			format: SchemaFormatJSON,
+			schema: `{"items": [
+  {"id": "a", "label": "A", "type": "toggle"},
+  {"id": "b" "label": "B"}
+]}`,
+			line: 3,
+			msg:  "invalid character",
+		},
+		{
+			name:   "json type",
+			format: SchemaFormatJSON,
+			schema: `{"items": [
+  {"id": "a", "label": "A", "type": "toggle"},
+  {"id": "b", "label": 5}
+]}`,
+			line: 3,
+			msg:  "should be string",
+		},
+		{
+			name:   "json unknown type",
+			format: SchemaFormatJSON,
+			schema: `{"items": [
+  {"header": "General"},
+
+  {"id": "a", "label": "A", "type": "slider"}
+]}`,
+			line: 4,
+			msg:  `unknown type "slider"`,
+		},
+		{
+			name:   "json duplicate id",
+			format: SchemaFormatJSON,
+			schema: `{"items": [
+  {"id": "a", "label": "A", "type": "toggle"},
+  {"id": "a", "label": "Again", "type": "toggle"}
+]}`,
+			line: 3,
+			msg:  `duplicate id "a"`,
+		},
+		{
+			name:   "json unknown visible_when setting",
+			format: SchemaFormatJSON,
+			schema: `{"items": [
+  {"id": "a", "label": "A", "type": "toggle"},
+  {"id": "b", "label": "B", "type": "toggle",
+   "visible_when": {"setting": "c", "equals": true}}
+]}`,
+			line: 3,
+			msg:  `unknown setting "c"`,
+		},
+		{
+			name:   "json self-referencing visible_when",
+			format: SchemaFormatJSON,
+			schema: `{"items": [
+  {"id": "a", "label": "A", "type": "toggle",
+   "visible_when": {"setting": "a", "equals": true}}
+]}`,
+			line: 2,
+			msg:  "its own setting",
+		},
+		{
+			name:   "json visible_when without a test",
+			format: SchemaFormatJSON,
+			schema: `{"items": [
+  {"id": "a", "label": "A", "type": "toggle"},
+  {"id": "b", "label": "B", "type": "toggle", "visible_when": {"setting": "a"}}
+]}`,
+			line: 3,
+			msg:  "requires equals, not_equals or in",
+		},
+		{
+			name:   "json default matching no option",
+			format: SchemaFormatJSON,
+			schema: `{"items": [
+  {"id": "a", "label": "A", "type": "toggle"},
+  {"id": "b", "label": "B", "options": [
+    {"label": "Low", "value": 1}, {"label": "High", "value": 2}], "default": 3}
+]}`,
+			line: 3,
+			msg:  "matches none of its options",
+		},
+		{
+			name:   "toml toggle default matching no option",
+			format: SchemaFormatTOML,
+			schema: `[[items]]
+id = "a"
+label = "A"
+type = "toggle"
+default = "yes"
+`,
+			line: 1,
+			msg:  "matches none of its options",
+		},
+		{
+			name:   "toml syntax",
+			format: SchemaFormatTOML,
+			schema: `[[items]]
+id = "a"
+label = "A
+type = "toggle"
+`,
+			line: 3,
+			msg:  "",
+		},
+		{
+			name:   "toml type",
+			format: SchemaFormatTOML,
+			schema: `[[items]]
+id = "a"
+label = 5
+`,
+			line: 3,
+			msg:  "incompatible types",
+		},
+		{
+			name:   "toml duplicate id",
+			format: SchemaFormatTOML,
+			schema: `[[items]]
+id = "a"
+label = "A"
+type = "toggle"
+
+[[items]]
+id = "a"
+label = "Again"
+type = "toggle"
+`,
+			line: 6,
+			msg:  `duplicate id "a"`,
+		},
+		{
+			name:   "toml unknown visible_when setting",
+			format: SchemaFormatTOML,
+			schema: `[[items]]
+id = "a"
+label = "A"
+type = "toggle"
+visible_when = { setting = "missing", equals = true }
+`,
+			line: 1,
+			msg:  `unknown setting "missing"`,
+		},
+		{
+			name:   "toml inline items",
+			format: SchemaFormatTOML,
+			schema: `items = [
+  { header = "General" },
+  { id = "a", label = "A", type = "toggle" },
+  { id = "b", label = "B", type = "slider" },
+]
+`,
+			line: 4,
+			msg:  `unknown type "slider"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSettingsSchema([]byte(tt.schema), tt.format)
+
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("error = %v, want a *SchemaError", err)
+			}
+			if schemaErr.Line != tt.line {
+				t.Errorf("Line = %d, want %d (%v)", schemaErr.Line, tt.line, err)
+			}
+			if !strings.Contains(schemaErr.Msg, tt.msg) {
+				t.Errorf("Msg = %q, want it to contain %q", schemaErr.Msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestSchemaItemLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		format SchemaFormat
+		schema string
+		want   []int
+	}{
+		{
+			name:   "json",
+			format: SchemaFormatJSON,
+			schema: `{
+  "title": "ignored",
+  "items": [
+    {"header": "General"},
+    {"id": "a", "label": "A",
+     "type": "toggle"}, {"id": "b", "label": "B"}
+  ]
+}`,
+			want: []int{4, 5, 6},
+		},
+		{
+			name:   "json without items",
+			format: SchemaFormatJSON,
+			schema: `{"other": []}`,
+			want:   nil,
+		},
+		{
+			name:   "toml tables",
+			format: SchemaFormatTOML,
+			schema: `# settings
+[[items]]
+header = "General"
+
+  [[ items ]]
+id = "a"
+`,
+			want: []int{2, 5},
+		},
+		{
+			name:   "toml inline",
+			format: SchemaFormatTOML,
+			schema: `items = [ { header = "General" },  # the { in a comment is skipped
+  { id = "a", label = "Say \"}\"", options = [ { label = "x", value = 1 } ] },
+
+  {
+    id = "b", label = '{'
+  }
+]
+`,
+			want: []int{1, 2, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			if tt.format == SchemaFormatJSON {
+				got = jsonItemLines([]byte(tt.schema))
+			} else {
+				got = tomlItemLines([]byte(tt.schema))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("item lines = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingsSchemaConditionMatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		cond  settingsSchemaCondition
+		value interface{}
+		want  bool
+	}{
+		{name: "equals bool", cond: settingsSchemaCondition{Equals: true}, value: true, want: true},
+		{name: "equals bool mismatch", cond: settingsSchemaCondition{Equals: true}, value: false, want: false},
+		{name: "equals int and json float", cond: settingsSchemaCondition{Equals: float64(2)}, value: 2, want: true},
+		{name: "equals int and toml int64", cond: settingsSchemaCondition{Equals: int64(2)}, value: 2, want: true},
+		{name: "equals fractional float", cond: settingsSchemaCondition{Equals: 2.5}, value: 2.5, want: true},
+		{name: "equals string and number", cond: settingsSchemaCondition{Equals: "2"}, value: 2, want: true},
+		{name: "equals string", cond: settingsSchemaCondition{Equals: "dark"}, value: "light", want: false},
+		{name: "not_equals", cond: settingsSchemaCondition{NotEquals: "off"}, value: "on", want: true},
+		{name: "not_equals same", cond: settingsSchemaCondition{NotEquals: float64(0)}, value: 0, want: false},
+		{name: "in", cond: settingsSchemaCondition{In: []interface{}{"a", float64(3)}}, value: 3, want: true},
+		{name: "not in", cond: settingsSchemaCondition{In: []interface{}{"a", int64(3)}}, value: "b", want: false},
+		{name: "multiselect equals any", cond: settingsSchemaCondition{Equals: "gba"}, value: []interface{}{"gb", "gba"}, want: true},
+		{name: "multiselect equals none", cond: settingsSchemaCondition{Equals: "nes"}, value: []interface{}{"gb", "gba"}, want: false},
+		{name: "multiselect in", cond: settingsSchemaCondition{In: []interface{}{"nes", "gb"}}, value: []interface{}{"gb"}, want: true},
+		{name: "multiselect not_equals", cond: settingsSchemaCondition{NotEquals: "gb"}, value: []interface{}{"gb", "gba"}, want: false},
+		{name: "multiselect not_equals absent", cond: settingsSchemaCondition{NotEquals: "nes"}, value: []interface{}{"gb"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cond.matches(tt.value); got != tt.want {
+				t.Errorf("matches(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaValuesEqual(t *testing.T) {
+	tests := []struct {
+		a, b interface{}
+		want bool
+	}{
+		{a: 1, b: float64(1), want: true},
+		{a: int64(1), b: float64(1), want: true},
+		{a: 1, b: 1.5, want: false},
+		{a: 1.5, b: 1.5, want: true},
+		{a: "1", b: 1, want: true},
+		{a: true, b: "true", want: true},
+		{a: "a", b: "b", want: false},
+		{a: nil, b: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := schemaValuesEqual(tt.a, tt.b); got != tt.want {
+			t.Errorf("schemaValuesEqual(%#v, %#v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSettingsSchemaVisibility(t *testing.T) {
+	schema := `{"items": [
+  {"id": "sync", "label": "Auto Sync", "type": "toggle", "default": false},
+  {"id": "mode", "label": "Mode", "options": [
+    {"label": "Fast", "value": 1}, {"label": "Safe", "value": 2}], "default": 2},
+  {"id": "time", "label": "Sync At", "type": "time", "default": "03:00",
+   "visible_when": {"setting": "sync", "equals": true}},
+  {"id": "verify", "label": "Verify", "type": "toggle",
+   "visible_when": {"setting": "mode", "in": [2, 3]}}
+]}`
+
+	items, err := ParseSettingsSchema([]byte(schema), SchemaFormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if items[2].IsVisible() {
+		t.Error("time is visible while sync is off")
+	}
+	if !items[3].IsVisible() {
+		t.Error("verify is hidden while mode is 2")
+	}
+
+	items[0].Options[1].OnUpdate(true)
+	if !items[2].IsVisible() {
+		t.Error("time is hidden after sync was turned on")
+	}
+
+	items[1].Options[0].OnUpdate(1)
+	if items[3].IsVisible() {
+		t.Error("verify is visible after mode changed to 1")
+	}
+}
+
+func TestSettingsSchemaVisibilityKeepsCallbacks(t *testing.T) {
+	items := []ItemWithOptions{
+		{Item: MenuItem{Metadata: "a"}, Options: []Option{{Value: false}, {Value: true}}},
+		{Item: MenuItem{Metadata: "b"}, Options: []Option{{Value: "x"}}},
+	}
+	raw := []settingsSchemaItem{
+		{ID: "a"},
+		{ID: "b", VisibleWhen: &settingsSchemaCondition{Setting: "a", Equals: true}},
+	}
+
+	var got []interface{}
+	items[0].Options[1].OnUpdate = func(newValue interface{}) { got = append(got, newValue) }
+
+	compileSchemaVisibility(items, raw, map[string]int{"a": 0, "b": 1})
+	items[0].Options[1].OnUpdate(true)
+
+	if !items[1].IsVisible() {
+		t.Error("b is hidden after a was turned on")
+	}
+	if !reflect.DeepEqual(got, []interface{}{true}) {
+		t.Errorf("existing OnUpdate got %v, want [true]", got)
+	}
+}
+
+func TestSettingsSchemaKeyboardVisibility(t *testing.T) {
+	schema := `{"items": [
+  {"id": "server", "label": "Server", "type": "keyboard", "default": "local"},
+  {"id": "token", "label": "Token", "type": "keyboard",
+   "visible_when": {"setting": "server", "not_equals": "local"}}
+]}`
+
+	items, err := ParseSettingsSchema([]byte(schema), SchemaFormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if items[1].IsVisible() {
+		t.Error("token is visible while server is local")
+	}
+
+	items[0].setKeyboardText(0, "example.com", "example.com")
+	if !items[1].IsVisible() {
+		t.Error("token is hidden after server was changed")
+	}
+	if items[0].Options[0].OnUpdate == nil {
+		t.Fatal("keyboard edit dropped the visibility callback")
+	}
+
+	items[0].setKeyboardText(0, "local", "local")
+	if items[1].IsVisible() {
+		t.Error("token is visible after server was changed back to local")
+	}
+}
+
+func TestSettingsSchemaKeyboardPrompt(t *testing.T) {
+	schema := `[[items]]
+id = "name"
+label = "Name"
+type = "keyboard"
+default = "Player"
+prompt = "Your name"
+`
+
+	items, err := ParseSettingsSchema([]byte(schema), SchemaFormatTOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	option := items[0].Options[0]
+	if option.Value != "Player" || option.KeyboardPlaceholder != "Your name" {
+		t.Errorf("keyboard option = %q with placeholder %q, want \"Player\" with \"Your name\"", option.Value, option.KeyboardPlaceholder)
+	}
+}
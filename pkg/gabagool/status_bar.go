@@ -4,6 +4,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/i18n"
 	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
 	"github.com/veandco/go-sdl2/sdl"
 	"github.com/veandco/go-sdl2/ttf"
@@ -15,6 +16,7 @@ type TimeFormat int
 const (
 	TimeFormat24Hour TimeFormat = iota
 	TimeFormat12Hour
+	TimeFormatLocale // Follows the convention of the current i18n language
 )
 
 // DynamicStatusBarIcon allows goroutines to safely update icon content.
@@ -275,7 +277,7 @@ func renderStatusBar(
 
 func formatCurrentTime(format TimeFormat) string {
 	now := time.Now()
-	switch format {
+	switch resolveTimeFormat(format) {
 	case TimeFormat12Hour:
 		return now.Format("3:04 PM")
 	case TimeFormat24Hour:
@@ -285,6 +287,17 @@ func formatCurrentTime(format TimeFormat) string {
 	}
 }
 
+// resolveTimeFormat maps TimeFormatLocale to a concrete 12 or 24-hour format.
+func resolveTimeFormat(format TimeFormat) TimeFormat {
+	if format != TimeFormatLocale {
+		return format
+	}
+	if internal.Uses12HourClock(i18n.CurrentLanguage()) {
+		return TimeFormat12Hour
+	}
+	return TimeFormat24Hour
+}
+
 func renderStatusBarTime(
 	renderer *sdl.Renderer,
 	font *ttf.Font,
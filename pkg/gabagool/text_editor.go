@@ -0,0 +1,318 @@
+package gabagool
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// TextEditorOptions configures TextEditor.
+type TextEditorOptions struct {
+	// Languages enabled on the keyboard.
+	// If empty, SetDefaultKeyboardLanguages or the active i18n locale decides.
+	Languages []KeyboardLanguage
+
+	// MaxLength caps the text at this many characters, line breaks included (0 = unlimited)
+	MaxLength int
+
+	// AllowedRunes restricts which characters can be entered (nil = any).
+	// Line breaks are only possible when '\n' is allowed.
+	AllowedRunes func(r rune) bool
+
+	// Placeholder is shown while the text is empty
+	Placeholder string
+}
+
+// TextEditorResult represents the text returned by a TextEditor.
+type TextEditorResult struct {
+	Text string
+}
+
+var textEditorHelpLines = []string{
+	"• Enter key: Insert a line break",
+	"• Select + Up / Down: Move cursor between lines",
+	"• Start: Save and exit",
+}
+
+// textArea is the multi-line text box that replaces the single-line input field.
+type textArea struct {
+	font       *ttf.Font
+	statusFont *ttf.Font
+
+	wrapWidth   int32
+	wrappedText string
+	runes       []rune
+	spans       []internal.TextSpan
+
+	firstLine int
+
+	// Horizontal position kept while moving up and down, so the cursor returns to
+	// the same column after passing a shorter line
+	goalX        int32
+	goalPosition int
+}
+
+// TextEditor displays a multi-line text editor above the on-screen keyboard.
+// The Enter key inserts line breaks, Select + Up/Down moves between lines and
+// Start saves. Lines wrap at word boundaries to fit the editor.
+// Returns ErrCancelled if the user exits without saving.
+func TextEditor(initialText string, helpExitText string, options ...TextEditorOptions) (*TextEditorResult, error) {
+	var opts TextEditorOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	window := internal.GetWindow()
+	kb := createKeyboard(window.GetWidth(), window.GetHeight(), helpExitText, KeyboardLayoutGeneral, resolveKeyboardLanguages(opts.Languages))
+	kb.enableTextArea(window.GetWidth(), window.GetHeight())
+	kb.applyOptions(KeyboardOptions{
+		MaxLength:    opts.MaxLength,
+		AllowedRunes: opts.AllowedRunes,
+		Placeholder:  opts.Placeholder,
+	})
+
+	initialText = strings.ReplaceAll(strings.ReplaceAll(initialText, "\r\n", "\n"), "\r", "\n")
+	result, err := runKeyboard(kb, initialText)
+	if err != nil {
+		return nil, err
+	}
+	return &TextEditorResult{Text: result.Text}, nil
+}
+
+// enableTextArea switches the keyboard to multi-line editing and lays it out again
+// to make room for the taller text box.
+func (kb *virtualKeyboard) enableTextArea(windowWidth, windowHeight int32) {
+	kb.textArea = &textArea{
+		font:         internal.Fonts.SmallFont,
+		statusFont:   internal.Fonts.TinyFont,
+		goalPosition: -1,
+	}
+	kb.applyLanguage(windowWidth, windowHeight)
+
+	if kb.helpOverlay != nil {
+		kb.helpOverlay.Title = "Text Editor Help"
+		kb.helpOverlay.Lines = append(append([]string(nil), kb.helpOverlay.Lines...), textEditorHelpLines...)
+	}
+}
+
+// reserveTextArea grows the text box to two fifths of the screen and squeezes the keys below it.
+func (kb *virtualKeyboard) reserveTextArea() {
+	ta := kb.textArea
+	if ta == nil {
+		return
+	}
+
+	top := kb.TextInputRect.Y
+	total := kb.KeyboardRect.Y + kb.KeyboardRect.H - top
+	spacing := int32(10)
+
+	kb.TextInputRect.H = total * 2 / 5
+	kb.squeezeKeys(top + kb.TextInputRect.H + spacing)
+
+	ta.wrapWidth = kb.textAreaRect().W
+	ta.spans = nil
+}
+
+// textAreaRect returns the region of the text box used for text, leaving room for
+// the line/column indicator at the bottom.
+func (kb *virtualKeyboard) textAreaRect() sdl.Rect {
+	padding := int32(10)
+	statusHeight := int32(kb.textArea.statusFont.Height())
+	return sdl.Rect{
+		X: kb.TextInputRect.X + padding,
+		Y: kb.TextInputRect.Y + padding,
+		W: kb.TextInputRect.W - padding*2,
+		H: kb.TextInputRect.H - padding*2 - statusHeight,
+	}
+}
+
+func (ta *textArea) measure(line []rune) int32 {
+	width, _, err := ta.font.SizeUTF8(string(line))
+	if err != nil {
+		return 0
+	}
+	return int32(width)
+}
+
+// wrap returns the wrapped lines of text, reusing the previous result while the text is unchanged.
+func (ta *textArea) wrap(text string) ([]rune, []internal.TextSpan) {
+	if ta.spans == nil || text != ta.wrappedText {
+		ta.wrappedText = text
+		ta.runes = []rune(text)
+		ta.spans = internal.WrapSpans(ta.runes, ta.wrapWidth, ta.measure, true)
+	}
+	return ta.runes, ta.spans
+}
+
+// moveLine moves the cursor to the wrapped line above or below, keeping its horizontal position.
+func (kb *virtualKeyboard) moveLine(direction int, extend bool) {
+	ta := kb.textArea
+	if ta == nil {
+		return
+	}
+
+	kb.commitComposition()
+
+	runes, spans := ta.wrap(kb.TextBuffer)
+	line := internal.SpanForPosition(spans, kb.CursorPosition)
+	if ta.goalPosition != kb.CursorPosition {
+		ta.goalX = ta.measure(runes[spans[line].Start:kb.CursorPosition])
+	}
+
+	var position int
+	switch target := line + direction; {
+	case target < 0:
+		position = 0
+	case target >= len(spans):
+		position = len(runes)
+	default:
+		position = internal.PositionForX(runes, spans[target], ta.goalX, ta.measure)
+	}
+
+	if extend {
+		kb.extendSelectionTo(position)
+	} else {
+		kb.moveCursorTo(position)
+	}
+	ta.goalPosition = kb.CursorPosition
+}
+
+// lineStart returns where Home moves the cursor: the start of the wrapped line
+// in the editor, or the start of the text.
+func (kb *virtualKeyboard) lineStart() int {
+	if kb.textArea == nil {
+		return 0
+	}
+	_, spans := kb.textArea.wrap(kb.TextBuffer)
+	return spans[internal.SpanForPosition(spans, kb.CursorPosition)].Start
+}
+
+// lineEnd returns where End moves the cursor: the end of the wrapped line in the
+// editor, or the end of the text.
+func (kb *virtualKeyboard) lineEnd() int {
+	if kb.textArea == nil {
+		return utf8.RuneCountInString(kb.TextBuffer)
+	}
+	runes, spans := kb.textArea.wrap(kb.TextBuffer)
+	line := internal.SpanForPosition(spans, kb.CursorPosition)
+	return internal.PositionForX(runes, spans[line], 1<<30, kb.textArea.measure)
+}
+
+// cursorLineColumn returns the 1-based line and column of the cursor, counting
+// line breaks rather than wrapped lines.
+func (kb *virtualKeyboard) cursorLineColumn() (line, column int) {
+	line, column = 1, 1
+	for _, r := range []rune(kb.TextBuffer)[:kb.CursorPosition] {
+		if r == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+func (kb *virtualKeyboard) renderTextArea(renderer *sdl.Renderer) {
+	ta := kb.textArea
+
+	renderer.SetDrawColor(50, 50, 50, 255)
+	renderer.FillRect(&kb.TextInputRect)
+	renderer.SetDrawColor(200, 200, 200, 255)
+	renderer.DrawRect(&kb.TextInputRect)
+
+	area := kb.textAreaRect()
+	text, compositionStart, compositionEnd := kb.displayText()
+	runes, spans := ta.wrap(text)
+
+	lineHeight := int32(ta.font.Height())
+	visibleLines := max(int(area.H/lineHeight), 1)
+	cursorLine := internal.SpanForPosition(spans, compositionEnd)
+
+	// Keep the cursor line on screen
+	if cursorLine < ta.firstLine {
+		ta.firstLine = cursorLine
+	} else if cursorLine >= ta.firstLine+visibleLines {
+		ta.firstLine = cursorLine - visibleLines + 1
+	}
+	ta.firstLine = max(0, min(ta.firstLine, len(spans)-visibleLines))
+
+	if text == "" && kb.options.Placeholder != "" {
+		ta.renderLine(renderer, ta.font, kb.options.Placeholder, area.X, area.Y, sdl.Color{R: 130, G: 130, B: 130, A: 255})
+	}
+
+	selectionStart, selectionEnd, hasSelection := kb.selectionRange()
+	hasSelection = hasSelection && compositionEnd == compositionStart
+
+	for i := ta.firstLine; i < len(spans) && i < ta.firstLine+visibleLines; i++ {
+		span := spans[i]
+		y := area.Y + int32(i-ta.firstLine)*lineHeight
+		xAt := func(pos int) int32 {
+			return area.X + ta.measure(runes[span.Start:pos])
+		}
+
+		if hasSelection && selectionStart <= span.End && selectionEnd > span.Start {
+			start, end := max(selectionStart, span.Start), min(selectionEnd, span.End)
+			highlight := sdl.Rect{X: xAt(start), Y: y, W: xAt(end) - xAt(start), H: lineHeight}
+			if selectionEnd > span.End {
+				// Show the selected line break
+				highlight.W += lineHeight / 3
+			}
+			renderer.SetDrawColor(100, 100, 240, 255)
+			renderer.FillRect(&highlight)
+		}
+
+		ta.renderLine(renderer, ta.font, string(runes[span.Start:span.End]), area.X, y, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+
+		// Underline the in-progress composition
+		if compositionEnd > compositionStart && compositionStart < span.End && compositionEnd > span.Start {
+			start, end := max(compositionStart, span.Start), min(compositionEnd, span.End)
+			underline := sdl.Rect{X: xAt(start), Y: y + lineHeight - 2, W: xAt(end) - xAt(start), H: 2}
+			renderer.SetDrawColor(255, 255, 255, 255)
+			renderer.FillRect(&underline)
+		}
+
+		if i == cursorLine && kb.CursorVisible {
+			cursorRect := sdl.Rect{X: internal.Min32(xAt(compositionEnd), area.X+area.W), Y: y, W: 2, H: lineHeight}
+			renderer.SetDrawColor(255, 255, 255, 255)
+			renderer.FillRect(&cursorRect)
+		}
+	}
+
+	if len(spans) > visibleLines {
+		trackHeight := area.H
+		handleHeight := internal.Max32(trackHeight*int32(visibleLines)/int32(len(spans)), 10)
+		handleY := area.Y + (trackHeight-handleHeight)*int32(ta.firstLine)/int32(len(spans)-visibleLines)
+		internal.DrawSmoothScrollbar(renderer, kb.TextInputRect.X+kb.TextInputRect.W-6, handleY, 3, handleHeight, sdl.Color{R: 150, G: 150, B: 150, A: 255})
+	}
+
+	line, column := kb.cursorLineColumn()
+	status := fmt.Sprintf("Ln %d, Col %d", line, column)
+	if width, _, err := ta.statusFont.SizeUTF8(status); err == nil {
+		ta.renderLine(renderer, ta.statusFont, status, area.X+area.W-int32(width), area.Y+area.H+5, sdl.Color{R: 150, G: 150, B: 150, A: 255})
+	}
+}
+
+func (ta *textArea) renderLine(renderer *sdl.Renderer, font *ttf.Font, text string, x, y int32, color sdl.Color) {
+	if text == "" {
+		return
+	}
+
+	surface, err := font.RenderUTF8Blended(text, color)
+	if err != nil {
+		return
+	}
+	defer surface.Free()
+
+	texture, err := renderer.CreateTextureFromSurface(surface)
+	if err != nil {
+		return
+	}
+	defer texture.Destroy()
+
+	renderer.Copy(texture, nil, &sdl.Rect{X: x, Y: y, W: surface.W, H: surface.H})
+}
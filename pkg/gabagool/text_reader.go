@@ -0,0 +1,490 @@
+package gabagool
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// TextReaderFormat sets how TextReader interprets its text.
+type TextReaderFormat int
+
+const (
+	TextReaderFormatPlain    TextReaderFormat = iota // Shown as is, keeping line breaks
+	TextReaderFormatMarkdown                         // Formatted like markdown sections
+)
+
+// TextReaderOptions configures the TextReader component.
+type TextReaderOptions struct {
+	Format          TextReaderFormat
+	DocumentKey     string           // Remembers the reading position and text size under this key (empty = not saved)
+	FontSize        int              // Text size from -2 (smallest) to 2 (largest), 0 = normal; a saved size takes precedence
+	TextColor       sdl.Color        // Body text color (default: light gray)
+	TitleColor      sdl.Color        // Title and heading color (default: white)
+	BackgroundColor sdl.Color        // Screen background color (default: black)
+	FooterHelpItems []FooterHelpItem // Footer items (default: the reader controls)
+	HelpExitText    string           // Help exit text shown by the search keyboard
+	StatusBar       StatusBarOptions // Status icons configuration
+}
+
+// TextReaderResult represents the result of the TextReader component.
+type TextReaderResult struct {
+	Page      int // Page showing when the reader was closed, from 0
+	PageCount int
+}
+
+const (
+	textReaderMinFontSize = -2
+	textReaderMaxFontSize = 2
+)
+
+type textReaderState struct {
+	window   *internal.Window
+	renderer *sdl.Renderer
+	text     string
+	options  TextReaderOptions
+
+	fontSize   int
+	layout     *markdownLayout
+	rowTops    []int32
+	rowBottoms []int32
+	pages      []int // first row of each page
+	page       int
+	pageRect   sdl.Rect
+
+	titleTexture *sdl.Texture
+	query        string
+	matches      [][]internal.TextPart // parts of the fragments each match covers, in runes
+	match        int                   // current match, -1 = none
+
+	textureCache  *internal.TextureCache
+	lastInputTime time.Time
+	inputDelay    time.Duration
+	done          bool
+}
+
+// TextReader shows long text one screen-sized page at a time, as plain text or
+// Markdown. L1/R1 (or Left/Right) turn the page, L2/R2 change the text size, Y
+// searches the text and X jumps to the next match. B closes the reader, and with a
+// DocumentKey the position is saved so the document reopens where it was left.
+func TextReader(title string, text string, options TextReaderOptions) (*TextReaderResult, error) {
+	window := internal.GetWindow()
+	state := &textReaderState{
+		window:        window,
+		renderer:      window.Renderer,
+		text:          text,
+		options:       options,
+		fontSize:      options.FontSize,
+		match:         -1,
+		textureCache:  internal.NewTextureCache(),
+		lastInputTime: time.Now(),
+		inputDelay:    constants.DefaultInputDelay,
+	}
+	state.applyDefaults()
+	defer state.cleanup()
+
+	progress := 0.0
+	if options.DocumentKey != "" {
+		if saved, ok := getTextReaderPosition(options.DocumentKey); ok {
+			progress = saved.Progress
+			state.fontSize = saved.FontSize
+		}
+	}
+	state.fontSize = max(textReaderMinFontSize, min(state.fontSize, textReaderMaxFontSize))
+
+	if title != "" {
+		state.titleTexture = renderText(state.renderer, title, internal.Fonts.MediumFont, state.options.TitleColor)
+	}
+	state.calculatePageRect()
+	state.layoutText(progress)
+
+	for !state.done {
+		state.handleEvents()
+		state.render()
+	}
+
+	if options.DocumentKey != "" {
+		position := textReaderPosition{Progress: state.progress(), FontSize: state.fontSize}
+		if err := setTextReaderPosition(options.DocumentKey, position); err != nil {
+			internal.GetInternalLogger().Error("Failed to save reading position", "key", options.DocumentKey, "error", err)
+		}
+	}
+
+	return &TextReaderResult{Page: state.page, PageCount: len(state.pages)}, nil
+}
+
+func (s *textReaderState) applyDefaults() {
+	if s.options.TextColor.A == 0 {
+		s.options.TextColor = sdl.Color{R: 200, G: 200, B: 200, A: 255}
+	}
+	if s.options.TitleColor.A == 0 {
+		s.options.TitleColor = sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	}
+	if s.options.BackgroundColor.A == 0 {
+		s.options.BackgroundColor = sdl.Color{R: 0, G: 0, B: 0, A: 255}
+	}
+	if s.options.FooterHelpItems == nil {
+		s.options.FooterHelpItems = []FooterHelpItem{
+			{ButtonName: "B", HelpText: "Close"},
+			{ButtonName: "L1/R1", HelpText: "Page"},
+			{ButtonName: "L2/R2", HelpText: "Text Size"},
+			{ButtonName: "Y", HelpText: "Search"},
+		}
+	}
+}
+
+func (s *textReaderState) cleanup() {
+	s.layout.destroy()
+	if s.titleTexture != nil {
+		s.titleTexture.Destroy()
+	}
+	s.textureCache.Destroy()
+}
+
+// textReaderFonts returns the fonts for a text size, stepping headings up with the body.
+func textReaderFonts(size int) markdownFonts {
+	fonts := []*ttf.Font{
+		internal.Fonts.MicroFont,
+		internal.Fonts.TinyFont,
+		internal.Fonts.SmallFont,
+		internal.Fonts.MediumFont,
+		internal.Fonts.LargeFont,
+		internal.Fonts.ExtraLargeFont,
+	}
+	body := 2 + size
+	return markdownFonts{
+		body:     fonts[body],
+		heading1: fonts[min(body+2, len(fonts)-1)],
+		heading2: fonts[min(body+1, len(fonts)-1)],
+	}
+}
+
+// layoutPlainText lays out text as is, wrapping long lines and keeping blank ones.
+func layoutPlainText(text string, width int32, font *ttf.Font, color sdl.Color) *markdownLayout {
+	layout := &markdownLayout{width: width}
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\t", "    ")
+
+	y := int32(0)
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			y += markdownLineGap
+		}
+		y = layout.addRuns([]internal.MarkdownRun{{Text: line}}, font, ttf.STYLE_NORMAL, color, 0, y, width)
+	}
+
+	layout.height = y
+	return layout
+}
+
+// calculatePageRect sets the area pages are drawn in: below the title, above the page
+// indicator and footer.
+func (s *textReaderState) calculatePageRect() {
+	margins := internal.UniformPadding(20)
+	footerHeight := int32(30)
+
+	top := margins.Top
+	if s.titleTexture != nil {
+		_, _, _, titleH, _ := s.titleTexture.Query()
+		top += titleH + 15
+	}
+	indicatorHeight := int32(internal.Fonts.SmallFont.Height()) + 10
+
+	s.pageRect = sdl.Rect{
+		X: margins.Left,
+		Y: top,
+		W: s.window.GetWidth() - margins.Left - margins.Right,
+		H: s.window.GetHeight() - top - margins.Bottom - footerHeight - indicatorHeight,
+	}
+}
+
+// layoutText lays out and paginates the text at the current size, then opens the page
+// at progress through the document.
+func (s *textReaderState) layoutText(progress float64) {
+	s.layout.destroy()
+
+	fonts := textReaderFonts(s.fontSize)
+	if s.options.Format == TextReaderFormatMarkdown {
+		s.layout = layoutMarkdown(s.text, s.pageRect.W, fonts, s.options.TextColor, s.options.TitleColor)
+	} else {
+		s.layout = layoutPlainText(s.text, s.pageRect.W, fonts.body, s.options.TextColor)
+	}
+
+	// Pages break between lines of text, which may mix fonts
+	bottoms := make(map[int32]int32)
+	for _, fragment := range s.layout.fragments {
+		bottom := fragment.rect.Y + fragment.rect.H
+		if current, ok := bottoms[fragment.rect.Y]; !ok || bottom > current {
+			bottoms[fragment.rect.Y] = bottom
+		}
+	}
+	s.rowTops = slices.Sorted(maps.Keys(bottoms))
+	s.rowBottoms = make([]int32, len(s.rowTops))
+	for i, top := range s.rowTops {
+		s.rowBottoms[i] = bottoms[top]
+	}
+
+	s.pages = internal.PaginateRows(s.rowTops, s.rowBottoms, s.pageRect.H)
+	s.page = s.pageAt(int32(progress * float64(s.layout.height)))
+	s.findMatches()
+}
+
+func (s *textReaderState) pageTop(page int) int32 {
+	if page == 0 || len(s.rowTops) == 0 {
+		return 0
+	}
+	return s.rowTops[s.pages[page]]
+}
+
+func (s *textReaderState) pageEnd(page int) int32 {
+	if page+1 < len(s.pages) {
+		return s.pageTop(page + 1)
+	}
+	return s.layout.height
+}
+
+// pageAt returns the page showing the document at y.
+func (s *textReaderState) pageAt(y int32) int {
+	page := 0
+	for page+1 < len(s.pages) && s.pageTop(page+1) <= y {
+		page++
+	}
+	return page
+}
+
+// progress returns how far through the document the current page starts, from 0 to 1.
+func (s *textReaderState) progress() float64 {
+	if s.layout == nil || s.layout.height <= 0 {
+		return 0
+	}
+	return float64(s.pageTop(s.page)) / float64(s.layout.height)
+}
+
+func (s *textReaderState) turnPage(step int) {
+	s.page = max(0, min(s.page+step, len(s.pages)-1))
+}
+
+func (s *textReaderState) changeFontSize(step int) {
+	size := max(textReaderMinFontSize, min(s.fontSize+step, textReaderMaxFontSize))
+	if size == s.fontSize {
+		return
+	}
+	s.fontSize = size
+	s.layoutText(s.progress())
+}
+
+func (s *textReaderState) findMatches() {
+	s.matches = nil
+	s.match = -1
+	if s.query == "" {
+		return
+	}
+
+	// Fragments are searched as one text so matches can cross a line wrap or a change
+	// of style. Fragments that don't follow on from the previous one are joined with a
+	// space, standing in for the space a wrap consumes or the gap between blocks.
+	fragments := s.layout.fragments
+	texts := make([]string, len(fragments))
+	joins := make([]string, len(fragments))
+	for i, fragment := range fragments {
+		texts[i] = fragment.text
+		if i > 0 {
+			previous := fragments[i-1].rect
+			if fragment.rect.Y != previous.Y || fragment.rect.X != previous.X+previous.W {
+				joins[i] = " "
+			}
+		}
+	}
+	s.matches = internal.FindFoldJoined(texts, joins, s.query)
+}
+
+// search asks for a query on the keyboard and goes to its first match from the
+// current page on.
+func (s *textReaderState) search() {
+	result, err := KeyboardWithOptions(s.query, s.options.HelpExitText, KeyboardOptions{})
+	s.lastInputTime = time.Now()
+	if err != nil {
+		return
+	}
+
+	s.query = strings.TrimSpace(result.Text)
+	s.findMatches()
+
+	pageTop := s.pageTop(s.page)
+	for i, match := range s.matches {
+		if s.layout.fragments[match[0].Index].rect.Y >= pageTop {
+			s.goToMatch(i)
+			return
+		}
+	}
+	if len(s.matches) > 0 {
+		s.goToMatch(0)
+	}
+}
+
+func (s *textReaderState) goToMatch(index int) {
+	s.match = index
+	s.page = s.pageAt(s.layout.fragments[s.matches[index][0].Index].rect.Y)
+}
+
+func (s *textReaderState) handleEvents() {
+	processor := internal.GetInputProcessor()
+
+	if event := sdl.WaitEventTimeout(16); event != nil {
+		switch event.(type) {
+		case *sdl.QuitEvent:
+			s.done = true
+		case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.ControllerAxisEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent:
+			inputEvent := processor.ProcessSDLEvent(event.(sdl.Event))
+			if inputEvent == nil || !inputEvent.Pressed {
+				return
+			}
+			if time.Since(s.lastInputTime) < s.inputDelay {
+				return
+			}
+			s.lastInputTime = time.Now()
+			s.handleInput(inputEvent.Button)
+		}
+	}
+}
+
+func (s *textReaderState) handleInput(button constants.VirtualButton) {
+	switch button {
+	case constants.VirtualButtonB:
+		s.done = true
+	case constants.VirtualButtonL1, constants.VirtualButtonLeft:
+		s.turnPage(-1)
+	case constants.VirtualButtonR1, constants.VirtualButtonRight:
+		s.turnPage(1)
+	case constants.VirtualButtonL2:
+		s.changeFontSize(-1)
+	case constants.VirtualButtonR2:
+		s.changeFontSize(1)
+	case constants.VirtualButtonY:
+		s.search()
+	case constants.VirtualButtonX:
+		if len(s.matches) > 0 {
+			s.goToMatch((s.match + 1) % len(s.matches))
+		}
+	}
+}
+
+func (s *textReaderState) render() {
+	bg := s.options.BackgroundColor
+	s.renderer.SetDrawColor(bg.R, bg.G, bg.B, bg.A)
+	s.renderer.Clear()
+
+	margins := internal.UniformPadding(20)
+	s.renderTitle(margins)
+	s.renderPage()
+	s.renderPageIndicator()
+
+	renderStatusBar(s.renderer, internal.Fonts.SmallFont, s.options.StatusBar, margins)
+	renderFooter(s.renderer, internal.Fonts.SmallFont, s.options.FooterHelpItems, margins.Bottom, false, true)
+
+	s.window.Present()
+}
+
+func (s *textReaderState) renderTitle(margins internal.Padding) {
+	if s.titleTexture == nil {
+		return
+	}
+
+	_, _, titleW, titleH, err := s.titleTexture.Query()
+	if err != nil {
+		return
+	}
+
+	statusBarWidth := calculateStatusBarWidth(internal.Fonts.SmallFont, s.options.StatusBar)
+	displayWidth := internal.Min32(titleW, s.window.GetWidth()-margins.Left-margins.Right-statusBarWidth)
+	s.renderer.Copy(s.titleTexture,
+		&sdl.Rect{W: displayWidth, H: titleH},
+		&sdl.Rect{X: margins.Left, Y: margins.Top, W: displayWidth, H: titleH})
+}
+
+// renderPage draws the part of the layout on the current page, with search matches
+// highlighted. Textures for text on other pages are released.
+func (s *textReaderState) renderPage() {
+	top, end := s.pageTop(s.page), s.pageEnd(s.page)
+	clip := s.pageRect
+	clip.H = internal.Min32(clip.H, end-top)
+	s.renderer.SetClipRect(&clip)
+	defer s.renderer.SetClipRect(nil)
+
+	offsetX, offsetY := s.pageRect.X, s.pageRect.Y-top
+
+	for _, fill := range s.layout.fills {
+		if fill.rect.Y+fill.rect.H <= top || fill.rect.Y >= end {
+			continue
+		}
+		rect := fill.rect
+		rect.X += offsetX
+		rect.Y += offsetY
+		s.renderer.SetDrawColor(fill.color.R, fill.color.G, fill.color.B, fill.color.A)
+		s.renderer.FillRect(&rect)
+	}
+
+	for i := range s.layout.fragments {
+		fragment := &s.layout.fragments[i]
+		if fragment.rect.Y < top || fragment.rect.Y >= end {
+			fragment.releaseTexture()
+			continue
+		}
+
+		rect := fragment.rect
+		rect.X += offsetX
+		rect.Y += offsetY
+		s.renderMatches(i, rect)
+		fragment.render(s.renderer, rect)
+	}
+}
+
+func (s *textReaderState) renderMatches(fragmentIndex int, rect sdl.Rect) {
+	fragment := s.layout.fragments[fragmentIndex]
+	text := []rune(fragment.text)
+
+	for i, match := range s.matches {
+		for _, part := range match {
+			if part.Index != fragmentIndex {
+				continue
+			}
+
+			x := markdownTextWidth(fragment.font, fragment.style, string(text[:part.Start]))
+			w := markdownTextWidth(fragment.font, fragment.style, string(text[part.Start:part.End]))
+			if i == s.match {
+				s.renderer.SetDrawColor(100, 100, 200, 255)
+			} else {
+				s.renderer.SetDrawColor(80, 80, 120, 255)
+			}
+			s.renderer.FillRect(&sdl.Rect{X: rect.X + x - 1, Y: rect.Y, W: w + 2, H: rect.H})
+		}
+	}
+}
+
+func (s *textReaderState) renderPageIndicator() {
+	indicator := fmt.Sprintf("%d / %d", s.page+1, len(s.pages))
+	if s.query != "" {
+		if len(s.matches) == 0 {
+			indicator += "  ·  No matches"
+		} else if s.match >= 0 {
+			indicator += fmt.Sprintf("  ·  Match %d of %d", s.match+1, len(s.matches))
+		}
+	}
+
+	internal.RenderMultilineTextWithCache(
+		s.renderer,
+		indicator,
+		internal.Fonts.SmallFont,
+		s.pageRect.W,
+		s.pageRect.X,
+		s.pageRect.Y+s.pageRect.H+10,
+		sdl.Color{R: 150, G: 150, B: 150, A: 255},
+		constants.TextAlignCenter,
+		s.textureCache)
+}
@@ -0,0 +1,47 @@
+package gabagool
+
+import "github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+
+// Text reader positions.
+//
+// Readers opened with TextReaderOptions.DocumentKey remember how far through the
+// document the reader was, and the text size, in a small JSON file shared by all keys,
+// or only in memory until SetTextReaderPositionPath is called.
+// The position is kept as a fraction of the document so it survives a change of text
+// size or screen.
+
+var textReaderPositionStore internal.JSONStore[textReaderPosition]
+
+type textReaderPosition struct {
+	Progress float64 `json:"progress"` // 0 = start, 1 = end
+	FontSize int     `json:"font_size"`
+}
+
+// SetTextReaderPositionPath sets the file text reader positions are stored in. Until
+// it is set, positions are kept in memory and lost when the app exits. Parent
+// directories are created when a position is first saved.
+func SetTextReaderPositionPath(path string) {
+	textReaderPositionStore.SetPath(path)
+}
+
+// ClearTextReaderPosition forgets the reading position for a document key.
+func ClearTextReaderPosition(key string) error {
+	return textReaderPositionStore.Update(func(store map[string]textReaderPosition) bool {
+		if _, ok := store[key]; !ok {
+			return false
+		}
+		delete(store, key)
+		return true
+	})
+}
+
+func getTextReaderPosition(key string) (textReaderPosition, bool) {
+	return textReaderPositionStore.Get(key)
+}
+
+func setTextReaderPosition(key string, position textReaderPosition) error {
+	return textReaderPositionStore.Update(func(store map[string]textReaderPosition) bool {
+		store[key] = position
+		return true
+	})
+}